@@ -2,42 +2,97 @@ package audio
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"mime/multipart"
 	"net/http"
-	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
 	"github.com/andrejsstepanovs/go-litellm/request"
 )
 
-func setExtraHeaders(req *http.Request, extraHeaders map[string]string) {
-	for key, value := range extraHeaders {
-		key = strings.TrimSpace(key)
-		value = strings.TrimSpace(value)
-		if key == "" || value == "" {
-			continue
-		}
-		req.Header.Set(key, value)
+// TranscribeAudio uploads a local audio file. The multipart body is streamed, not buffered.
+func TranscribeAudio(url, token, filePath, model string, extraBody map[string]any, extraHeaders map[string]string) (*http.Response, error) {
+	upload, file, err := OpenUpload(filePath)
+	if err != nil {
+		return nil, err
 	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Warning: failed to close audio file: %v", err)
+		}
+	}()
+
+	return TranscribeUpload(context.Background(), url, token, upload, model, extraBody, extraHeaders)
 }
 
-func TranscribeAudio(url, token, filePath, model string, extraBody map[string]any, extraHeaders map[string]string) (*http.Response, error) {
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
+// TranscribeUpload streams upload as a multipart request body through an io.Pipe,
+// so the audio is never held in memory as a whole.
+func TranscribeUpload(ctx context.Context, url, token string, upload Upload, model string, extraBody map[string]any, extraHeaders map[string]string) (*http.Response, error) {
+	if upload.Reader == nil {
+		return nil, fmt.Errorf("upload reader cannot be nil")
+	}
+	if upload.Filename == "" {
+		return nil, fmt.Errorf("upload filename cannot be empty")
+	}
 
-	// Add model field
-	err := writer.WriteField("model", model)
+	fields, err := formFields(model, extraBody)
 	if err != nil {
-		return nil, fmt.Errorf("error writing model field: %w", err)
+		return nil, err
 	}
 
+	bodyReader, bodyWriter := io.Pipe()
+	writer := multipart.NewWriter(bodyWriter)
+
+	go func() {
+		bodyWriter.CloseWithError(writeMultipart(writer, fields, upload))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bodyReader)
+	if err != nil {
+		_ = bodyReader.CloseWithError(err)
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpresp.SetExtraHeaders(req, extraHeaders)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	// Send request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		// unblock the writer goroutine if the request failed before the body was read
+		_ = bodyReader.CloseWithError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+type formField struct {
+	key   string
+	value string
+}
+
+func formFields(model string, extraBody map[string]any) ([]formField, error) {
+	// Add model field
+	fields := []formField{{key: "model", value: model}}
+
 	// Add extra body fields
 	for key, value := range extraBody {
+		// array fields like "timestamp_granularities[]" are sent as repeated fields
+		if values, ok := value.([]string); ok && strings.HasSuffix(key, "[]") {
+			for _, v := range values {
+				fields = append(fields, formField{key: key, value: v})
+			}
+			continue
+		}
+
 		var strValue string
 		switch v := value.(type) {
 		case string:
@@ -55,66 +110,57 @@ func TranscribeAudio(url, token, filePath, model string, extraBody map[string]an
 			}
 			strValue = string(jsonBytes)
 		}
-		err := writer.WriteField(key, strValue)
+		fields = append(fields, formField{key: key, value: strValue})
+	}
+
+	return fields, nil
+}
+
+func writeMultipart(writer *multipart.Writer, fields []formField, upload Upload) error {
+	for _, field := range fields {
+		err := writer.WriteField(field.key, field.value)
 		if err != nil {
-			return nil, fmt.Errorf("error writing extra body field %s: %w", key, err)
+			return fmt.Errorf("error writing %s field: %w", field.key, err)
 		}
 	}
 
 	// Add file field
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("error opening file: %w", err)
-	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			panic(err)
-		}
-	}()
-
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	part, err := writer.CreateFormFile("file", upload.Filename)
 	if err != nil {
-		return nil, fmt.Errorf("error creating form file: %w", err)
+		return fmt.Errorf("error creating form file: %w", err)
 	}
 
-	_, err = io.Copy(part, file)
+	_, err = io.Copy(part, upload.reader())
 	if err != nil {
-		return nil, fmt.Errorf("error copying file content: %w", err)
+		return fmt.Errorf("error copying file content: %w", err)
 	}
 
 	err = writer.Close()
 	if err != nil {
-		return nil, fmt.Errorf("error closing writer: %w", err)
-	}
-
-	// Create request
-	req, err := http.NewRequest("POST", url, &body)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return fmt.Errorf("error closing writer: %w", err)
 	}
-
-	setExtraHeaders(req, extraHeaders)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	// Send request
-	client := &http.Client{}
-	return client.Do(req)
+	return nil
 }
 
 // Speech generates audio from text using OpenAI-compatible TTS API
 func Speech(url, token string, speechRequest request.Speech, extraHeaders map[string]string) (*http.Response, error) {
+	return SpeechWithContext(context.Background(), url, token, speechRequest, extraHeaders)
+}
+
+// SpeechWithContext is Speech bound to ctx. The response body is not read,
+// so callers can consume the audio while it is generated.
+func SpeechWithContext(ctx context.Context, url, token string, speechRequest request.Speech, extraHeaders map[string]string) (*http.Response, error) {
 	requestBody, err := json.Marshal(speechRequest)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling speech request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	setExtraHeaders(req, extraHeaders)
+	httpresp.SetExtraHeaders(req, extraHeaders)
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -152,3 +152,34 @@ func TestTranscribeAudio_WithoutExtraBody(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, `{"text":"hello world"}`, string(body))
 }
+
+func TestTranscribe_TypedOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(10 << 20)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "whisper-1", r.FormValue("model"))
+		assert.Equal(t, "lv", r.FormValue("language"))
+		assert.Equal(t, "Riga", r.FormValue("prompt"))
+		assert.Equal(t, "verbose_json", r.FormValue("response_format"))
+		assert.Equal(t, "0.2", r.FormValue("temperature"))
+		assert.Equal(t, []string{"word", "segment"}, r.MultipartForm.Value["timestamp_granularities[]"])
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	temperature := float32(0.2)
+	options := request.Transcription{
+		Language:               "lv",
+		Prompt:                 "Riga",
+		ResponseFormat:         request.TRANSCRIPTION_FORMAT_VERBOSE_JSON,
+		TimestampGranularities: []string{request.TIMESTAMP_GRANULARITY_WORD, request.TIMESTAMP_GRANULARITY_SEGMENT},
+		Temperature:            &temperature,
+	}
+	resp, err := audio.TranscribeAudio(server.URL, "test-token", "testdata/file_174.oga", "whisper-1", options.Fields(), nil)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
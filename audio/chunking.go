@@ -0,0 +1,256 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const levelWindow = 10 * time.Millisecond
+
+// ChunkOptions controls how long audio is split for transcription.
+type ChunkOptions struct {
+	// MaxDuration of one chunk. Defaults to 10 minutes.
+	MaxDuration time.Duration
+	// Overlap is repeated at the start of the next chunk when a chunk is cut
+	// at a fixed position. Defaults to 2 seconds.
+	Overlap time.Duration
+	// SplitOnSilence cuts at the longest pause shortly before MaxDuration.
+	// Chunks cut in silence do not overlap.
+	SplitOnSilence bool
+	// SilenceThreshold is the RMS level (0..1) below which audio is silence. Defaults to 0.01.
+	SilenceThreshold float64
+	// MinSilence is the shortest pause used as a cut point. Defaults to 300ms.
+	MinSilence time.Duration
+	// SearchWindow is how far before MaxDuration pauses are searched. Defaults to MaxDuration/5.
+	SearchWindow time.Duration
+	// Concurrency limits parallel chunk uploads. Defaults to 4.
+	Concurrency int
+	// PCMFormat treats input without a WAV header as raw PCM frames in this format.
+	PCMFormat *WAVFormat
+}
+
+func (o ChunkOptions) withDefaults() ChunkOptions {
+	if o.MaxDuration <= 0 {
+		o.MaxDuration = 10 * time.Minute
+	}
+	if o.Overlap < 0 {
+		o.Overlap = 0
+	} else if o.Overlap == 0 {
+		o.Overlap = 2 * time.Second
+	}
+	if o.SilenceThreshold <= 0 {
+		o.SilenceThreshold = 0.01
+	}
+	if o.MinSilence <= 0 {
+		o.MinSilence = 300 * time.Millisecond
+	}
+	if o.SearchWindow <= 0 || o.SearchWindow > o.MaxDuration {
+		o.SearchWindow = o.MaxDuration / 5
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	return o
+}
+
+// Chunk is a time range of the source audio.
+type Chunk struct {
+	Index int
+	Start time.Duration
+	End   time.Duration
+	// Overlap is the leading part that was also sent with the previous chunk.
+	Overlap time.Duration
+}
+
+// Chunks plans the split of w. Use a negative ChunkOptions.Overlap to disable overlap.
+func (w *WAV) Chunks(options ChunkOptions) ([]Chunk, error) {
+	options = options.withDefaults()
+	if options.Overlap >= options.MaxDuration {
+		return nil, fmt.Errorf("chunk overlap %s must be shorter than max duration %s", options.Overlap, options.MaxDuration)
+	}
+
+	total := w.Duration()
+	chunks := make([]Chunk, 0, int(total/options.MaxDuration)+1)
+	start, overlap := time.Duration(0), time.Duration(0)
+	for start < total {
+		end := start + options.MaxDuration
+		if end >= total {
+			chunks = append(chunks, Chunk{Index: len(chunks), Start: start, End: total, Overlap: overlap})
+			break
+		}
+
+		cut, next := end, end-options.Overlap
+		if options.SplitOnSilence {
+			pause, found, err := w.findPause(end-options.SearchWindow, end, options)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				cut, next = pause, pause
+			}
+		}
+
+		chunks = append(chunks, Chunk{Index: len(chunks), Start: start, End: cut, Overlap: overlap})
+		start, overlap = next, cut-next
+	}
+
+	return chunks, nil
+}
+
+// findPause returns the middle of the longest silent run between from and to.
+// Ties go to the later pause so chunks stay close to MaxDuration.
+func (w *WAV) findPause(from, to time.Duration, options ChunkOptions) (time.Duration, bool, error) {
+	levels, err := w.levels(from, to, levelWindow)
+	if err != nil {
+		return 0, false, err
+	}
+
+	minWindows := int(math.Ceil(float64(options.MinSilence) / float64(levelWindow)))
+	bestStart, bestLength := -1, 0
+	runStart := -1
+	for i := 0; i <= len(levels); i++ {
+		if i < len(levels) && levels[i] < options.SilenceThreshold {
+			if runStart < 0 {
+				runStart = i
+			}
+			continue
+		}
+		if runStart >= 0 {
+			length := i - runStart
+			if length >= minWindows && length >= bestLength {
+				bestStart, bestLength = runStart, length
+			}
+			runStart = -1
+		}
+	}
+
+	if bestStart < 0 {
+		return 0, false, nil
+	}
+	middle := from + time.Duration(bestStart)*levelWindow + time.Duration(bestLength)*levelWindow/2
+	return middle, true, nil
+}
+
+// ChunkResult is the transcription of one chunk with timestamps relative to the chunk start.
+type ChunkResult struct {
+	Chunk    Chunk
+	Response AudioResponse
+}
+
+// MergeChunks joins chunk transcriptions into one response. Segment and word
+// timestamps are shifted by the chunk start. Text heard twice in an overlap is
+// kept only once: timed items are split at the middle of the overlap and plain
+// text is de-duplicated by matching words.
+func MergeChunks(results []ChunkResult) AudioResponse {
+	merged := AudioResponse{
+		Words:    make([]Word, 0),
+		Segments: make([]Segment, 0),
+	}
+
+	texts := make([]string, 0, len(results))
+	for i, result := range results {
+		offset := float32(result.Chunk.Start.Seconds())
+		keepFrom := float32(math.Inf(-1))
+		if i > 0 && result.Chunk.Overlap > 0 {
+			keepFrom = float32((result.Chunk.Start + result.Chunk.Overlap/2).Seconds())
+		}
+		keepUntil := float32(math.Inf(1))
+		if i+1 < len(results) && results[i+1].Chunk.Overlap > 0 {
+			next := results[i+1].Chunk
+			keepUntil = float32((next.Start + next.Overlap/2).Seconds())
+		}
+
+		res := result.Response
+		if merged.Language == "" {
+			merged.Language = res.Language
+		}
+		if merged.Task == "" {
+			merged.Task = res.Task
+		}
+
+		words := make([]string, 0, len(res.Words))
+		for _, word := range res.Words {
+			word.Start += offset
+			word.End += offset
+			if word.Start < keepFrom || word.Start >= keepUntil {
+				continue
+			}
+			merged.Words = append(merged.Words, word)
+			words = append(words, word.Word)
+		}
+
+		segments := make([]string, 0, len(res.Segments))
+		for _, segment := range res.Segments {
+			segment.Start += offset
+			segment.End += offset
+			if segment.Start < keepFrom || segment.Start >= keepUntil {
+				continue
+			}
+			segment.ID = len(merged.Segments)
+			merged.Segments = append(merged.Segments, segment)
+			segments = append(segments, strings.TrimSpace(segment.Text))
+		}
+
+		switch {
+		case len(res.Segments) > 0:
+			texts = append(texts, strings.Join(segments, " "))
+		case len(res.Words) > 0:
+			texts = append(texts, strings.Join(words, " "))
+		case i > 0 && result.Chunk.Overlap > 0 && len(texts) > 0:
+			texts[len(texts)-1] = mergeOverlapText(texts[len(texts)-1], res.Text)
+		default:
+			texts = append(texts, strings.TrimSpace(res.Text))
+		}
+	}
+
+	nonEmpty := make([]string, 0, len(texts))
+	for _, text := range texts {
+		if text != "" {
+			nonEmpty = append(nonEmpty, text)
+		}
+	}
+	merged.Text = strings.Join(nonEmpty, " ")
+	if len(results) > 0 {
+		merged.Duration = float32(results[len(results)-1].Chunk.End.Seconds())
+	}
+
+	return merged
+}
+
+// maxOverlapWords limits how far back overlapping text is matched.
+const maxOverlapWords = 50
+
+// mergeOverlapText appends next to prev, dropping the leading words of next
+// that repeat the trailing words of prev. At least two words must match.
+func mergeOverlapText(prev, next string) string {
+	prevWords := strings.Fields(prev)
+	nextWords := strings.Fields(next)
+
+	limit := min(len(prevWords), len(nextWords), maxOverlapWords)
+	for k := limit; k >= 2; k-- {
+		if equalWords(prevWords[len(prevWords)-k:], nextWords[:k]) {
+			nextWords = nextWords[k:]
+			break
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(append(prevWords, nextWords...), " "))
+}
+
+func equalWords(a, b []string) bool {
+	for i := range a {
+		if normalizeWord(a[i]) != normalizeWord(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}))
+}
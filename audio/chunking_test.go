@@ -0,0 +1,114 @@
+package audio_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+)
+
+func testPCMWAV(t *testing.T, parts []time.Duration, tone []bool) *audio.WAV {
+	t.Helper()
+	data := testWAV(testPCM(parts, tone))
+	wav, err := audio.ReadWAV(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	return wav
+}
+
+func TestWAV_Chunks_Fixed(t *testing.T) {
+	wav := testPCMWAV(t, []time.Duration{25 * time.Second}, []bool{true})
+
+	chunks, err := wav.Chunks(audio.ChunkOptions{MaxDuration: 10 * time.Second, Overlap: 2 * time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, []audio.Chunk{
+		{Index: 0, Start: 0, End: 10 * time.Second},
+		{Index: 1, Start: 8 * time.Second, End: 18 * time.Second, Overlap: 2 * time.Second},
+		{Index: 2, Start: 16 * time.Second, End: 25 * time.Second, Overlap: 2 * time.Second},
+	}, chunks)
+
+	chunks, err = wav.Chunks(audio.ChunkOptions{MaxDuration: 10 * time.Second, Overlap: -1})
+	require.NoError(t, err)
+	assert.Len(t, chunks, 3)
+	assert.Equal(t, 10*time.Second, chunks[1].Start)
+	assert.Zero(t, chunks[1].Overlap)
+
+	_, err = wav.Chunks(audio.ChunkOptions{MaxDuration: time.Second, Overlap: time.Second})
+	assert.ErrorContains(t, err, "must be shorter")
+}
+
+func TestWAV_Chunks_Silence(t *testing.T) {
+	// speech 0-8s, pause 8-9s, speech 9-18s, pause 18-18.5s, speech 18.5-25s
+	wav := testPCMWAV(t,
+		[]time.Duration{8 * time.Second, time.Second, 9 * time.Second, 500 * time.Millisecond, 6500 * time.Millisecond},
+		[]bool{true, false, true, false, true},
+	)
+
+	chunks, err := wav.Chunks(audio.ChunkOptions{MaxDuration: 10 * time.Second, SplitOnSilence: true, SearchWindow: 3 * time.Second})
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+	assert.Equal(t, 8500*time.Millisecond, chunks[0].End)
+	assert.Equal(t, chunks[0].End, chunks[1].Start)
+	assert.Zero(t, chunks[1].Overlap)
+	assert.Equal(t, 18250*time.Millisecond, chunks[1].End)
+	assert.Equal(t, 25*time.Second, chunks[2].End)
+
+	// no pause in the search window falls back to a fixed cut with overlap
+	loud := testPCMWAV(t, []time.Duration{15 * time.Second}, []bool{true})
+	chunks, err = loud.Chunks(audio.ChunkOptions{MaxDuration: 10 * time.Second, SplitOnSilence: true})
+	require.NoError(t, err)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, 8*time.Second, chunks[1].Start)
+	assert.Equal(t, 2*time.Second, chunks[1].Overlap)
+}
+
+func TestMergeChunks_Timed(t *testing.T) {
+	results := []audio.ChunkResult{
+		{
+			Chunk: audio.Chunk{Index: 0, Start: 0, End: 10 * time.Second},
+			Response: audio.AudioResponse{
+				Language: "english",
+				Segments: []audio.Segment{{Start: 0, End: 5, Text: " hello there"}, {Start: 5, End: 9.5, Text: " general kenobi"}},
+				Words:    []audio.Word{{Word: "hello", Start: 0.5, End: 1}, {Word: "kenobi", Start: 8.6, End: 9.4}},
+			},
+		},
+		{
+			// overlap 8s-10s, split at 9s
+			Chunk: audio.Chunk{Index: 1, Start: 8 * time.Second, End: 15 * time.Second, Overlap: 2 * time.Second},
+			Response: audio.AudioResponse{
+				Segments: []audio.Segment{{Start: 0.5, End: 1.5, Text: " kenobi"}, {Start: 1.5, End: 6, Text: " you are a bold one"}},
+				Words:    []audio.Word{{Word: "kenobi", Start: 0.6, End: 1.4}, {Word: "bold", Start: 3, End: 3.5}},
+			},
+		},
+	}
+
+	merged := audio.MergeChunks(results)
+	assert.Equal(t, "hello there general kenobi you are a bold one", merged.Text)
+	assert.Equal(t, "english", merged.Language)
+	assert.Equal(t, float32(15), merged.Duration)
+
+	require.Len(t, merged.Segments, 3)
+	assert.Equal(t, []int{0, 1, 2}, []int{merged.Segments[0].ID, merged.Segments[1].ID, merged.Segments[2].ID})
+	assert.Equal(t, float32(9.5), merged.Segments[2].Start)
+	assert.Equal(t, float32(14), merged.Segments[2].End)
+
+	require.Len(t, merged.Words, 3)
+	assert.Equal(t, "kenobi", merged.Words[1].Word)
+	assert.Equal(t, float32(8.6), merged.Words[1].Start)
+	assert.Equal(t, float32(11), merged.Words[2].Start)
+}
+
+func TestMergeChunks_TextOnly(t *testing.T) {
+	results := []audio.ChunkResult{
+		{Chunk: audio.Chunk{Start: 0, End: 10 * time.Second}, Response: audio.AudioResponse{Text: "The quick brown fox jumps over"}},
+		{Chunk: audio.Chunk{Start: 8 * time.Second, End: 18 * time.Second, Overlap: 2 * time.Second}, Response: audio.AudioResponse{Text: "jumps over, the lazy dog."}},
+		{Chunk: audio.Chunk{Start: 18 * time.Second, End: 20 * time.Second}, Response: audio.AudioResponse{Text: "The end."}},
+	}
+
+	merged := audio.MergeChunks(results)
+	assert.Equal(t, "The quick brown fox jumps over the lazy dog. The end.", merged.Text)
+	assert.Empty(t, merged.Segments)
+}
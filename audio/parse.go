@@ -0,0 +1,129 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+// ParseTranscription parses a transcription body returned for the given response_format.
+// text is used as is, srt/vtt cues become Segments and all other formats are unmarshalled as json.
+func ParseTranscription(responseFormat string, body []byte) (AudioResponse, error) {
+	switch responseFormat {
+	case request.TRANSCRIPTION_FORMAT_TEXT:
+		return AudioResponse{Text: strings.TrimSpace(string(body))}, nil
+	case request.TRANSCRIPTION_FORMAT_SRT, request.TRANSCRIPTION_FORMAT_VTT:
+		segments, err := ParseSubtitles(string(body))
+		if err != nil {
+			return AudioResponse{}, fmt.Errorf("failed to parse %s transcription: %w", responseFormat, err)
+		}
+		return responseFromSegments(segments), nil
+	}
+
+	// json, verbose_json and provider specific formats passed through ExtraBody
+	var audioResponse AudioResponse
+	err := json.Unmarshal(body, &audioResponse)
+	if err != nil {
+		return AudioResponse{}, fmt.Errorf("failed to parse %s transcription: %w", responseFormat, err)
+	}
+	return audioResponse, nil
+}
+
+func responseFromSegments(segments []Segment) AudioResponse {
+	texts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		texts = append(texts, segment.Text)
+	}
+
+	res := AudioResponse{
+		Text:     strings.Join(texts, " "),
+		Segments: segments,
+	}
+	if len(segments) > 0 {
+		res.Duration = segments[len(segments)-1].End
+	}
+	return res
+}
+
+// ParseSubtitles reads SRT or WebVTT cues into Segments. Cue numbers, WEBVTT headers,
+// NOTE/STYLE/REGION blocks and cue settings are ignored.
+func ParseSubtitles(data string) ([]Segment, error) {
+	data = strings.ReplaceAll(strings.ReplaceAll(data, "\r\n", "\n"), "\r", "\n")
+	data = strings.TrimPrefix(data, "\ufeff")
+
+	segments := make([]Segment, 0)
+	for _, block := range strings.Split(data, "\n\n") {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		timing := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			continue
+		}
+
+		start, end, err := parseCueTiming(lines[timing])
+		if err != nil {
+			return nil, err
+		}
+
+		text := strings.TrimSpace(strings.Join(lines[timing+1:], "\n"))
+		segments = append(segments, Segment{
+			ID:    len(segments),
+			Start: start,
+			End:   end,
+			Text:  text,
+		})
+	}
+
+	return segments, nil
+}
+
+func parseCueTiming(line string) (float32, float32, error) {
+	from, to, _ := strings.Cut(line, "-->")
+	// WebVTT cue settings follow the end timestamp
+	toFields := strings.Fields(to)
+	if len(toFields) == 0 {
+		return 0, 0, fmt.Errorf("invalid cue timing %q", line)
+	}
+
+	start, err := parseTimestamp(strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseTimestamp(toFields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// parseTimestamp parses "hh:mm:ss,mmm", "hh:mm:ss.mmm" and "mm:ss.mmm" into seconds.
+func parseTimestamp(value string) (float32, error) {
+	parts := strings.Split(strings.ReplaceAll(value, ",", "."), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", value)
+	}
+
+	seconds := 0.0
+	for _, part := range parts[:len(parts)-1] {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp %q: %w", value, err)
+		}
+		seconds = seconds*60 + float64(n)
+	}
+
+	last, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q: %w", value, err)
+	}
+
+	return float32(seconds*60 + last), nil
+}
@@ -0,0 +1,68 @@
+package audio_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+)
+
+func TestParseTranscription(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		res, err := audio.ParseTranscription("json", []byte(`{"text":"hello world"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", res.Text)
+	})
+
+	t.Run("verbose_json with words", func(t *testing.T) {
+		res, err := audio.ParseTranscription("verbose_json", []byte(`{"text":"hi there","duration":1.5,"words":[{"word":"hi","start":0,"end":0.5},{"word":"there","start":0.6,"end":1.2}]}`))
+		require.NoError(t, err)
+		assert.Len(t, res.Words, 2)
+		assert.Equal(t, float32(1.5), res.Duration)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		res, err := audio.ParseTranscription("text", []byte("hello world\n"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", res.Text)
+	})
+
+	t.Run("srt", func(t *testing.T) {
+		body := "1\r\n00:00:00,000 --> 00:00:01,500\r\nhello\r\n\r\n2\r\n00:00:01,500 --> 00:01:02,250\r\nworld\r\nagain\r\n"
+		res, err := audio.ParseTranscription("srt", []byte(body))
+		require.NoError(t, err)
+		require.Len(t, res.Segments, 2)
+		assert.Equal(t, float32(1.5), res.Segments[0].End)
+		assert.Equal(t, float32(62.25), res.Segments[1].End)
+		assert.Equal(t, "world\nagain", res.Segments[1].Text)
+		assert.Equal(t, 1, res.Segments[1].ID)
+		assert.Equal(t, "hello world\nagain", res.Text)
+		assert.Equal(t, float32(62.25), res.Duration)
+	})
+
+	t.Run("vtt", func(t *testing.T) {
+		body := "WEBVTT\n\nNOTE generated\n\n00:00.000 --> 00:01.000 align:start\nhello\n\n00:00:01.000 --> 00:00:02.000\nworld\n"
+		res, err := audio.ParseTranscription("vtt", []byte(body))
+		require.NoError(t, err)
+		require.Len(t, res.Segments, 2)
+		assert.Equal(t, float32(1), res.Segments[0].End)
+		assert.Equal(t, "hello world", res.Text)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := audio.ParseTranscription("json", []byte("plain text"))
+		assert.Error(t, err)
+
+		_, err = audio.ParseTranscription("srt", []byte("1\nxx:00 --> 00:00:01,000\nhi"))
+		assert.ErrorContains(t, err, "invalid timestamp")
+
+	})
+
+	t.Run("unknown format falls back to json", func(t *testing.T) {
+		res, err := audio.ParseTranscription("diarized_json", []byte(`{"text":"hello world"}`))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", res.Text)
+	})
+}
@@ -0,0 +1,118 @@
+package audio
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+
+	"github.com/andrejsstepanovs/go-litellm/sse"
+)
+
+const (
+	SPEECH_STREAM_FORMAT_AUDIO = "audio"
+	SPEECH_STREAM_FORMAT_SSE   = "sse"
+
+	SPEECH_EVENT_DELTA = "speech.audio.delta"
+	SPEECH_EVENT_DONE  = "speech.audio.done"
+)
+
+// speechEvent is one server-sent event of a stream_format "sse" speech response.
+type speechEvent struct {
+	Type  string `json:"type"`
+	Audio string `json:"audio"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// errSpeechDone stops reading after the done event.
+var errSpeechDone = errors.New("speech stream done")
+
+// DecodeSpeechEvents writes the base64 audio deltas of a speech event stream to w
+// as each event arrives. It returns the number of audio bytes written.
+func DecodeSpeechEvents(r io.Reader, w io.Writer) (int64, error) {
+	var written int64
+	err := sse.Read(r, func(e sse.Event) error {
+		var event speechEvent
+		if err := json.Unmarshal(e.Data, &event); err != nil {
+			return fmt.Errorf("failed to parse speech event: %w", err)
+		}
+		if event.Error != nil {
+			return fmt.Errorf("speech stream error: %s", event.Error.Message)
+		}
+		if event.Audio != "" {
+			chunk, err := base64.StdEncoding.DecodeString(event.Audio)
+			if err != nil {
+				return fmt.Errorf("failed to decode speech audio delta: %w", err)
+			}
+			n, err := w.Write(chunk)
+			written += int64(n)
+			if err != nil {
+				return fmt.Errorf("failed to write speech audio: %w", err)
+			}
+		}
+		if event.Type == SPEECH_EVENT_DONE {
+			return errSpeechDone
+		}
+		return nil
+	})
+	if errors.Is(err, errSpeechDone) {
+		return written, nil
+	}
+	return written, err
+}
+
+// SpeechFile decides where TextToSpeech stores generated audio.
+type SpeechFile struct {
+	Directory string
+	// Filename returns the file name for the given extension.
+	Filename func(extension string) string
+}
+
+type SpeechFileOption func(*SpeechFile)
+
+// SpeechDirectory stores audio in dir instead of os.TempDir(). The directory is created when missing.
+func SpeechDirectory(dir string) SpeechFileOption {
+	return func(f *SpeechFile) {
+		f.Directory = dir
+	}
+}
+
+// SpeechFilename uses a fixed file name. The extension is appended when name has none.
+// An existing file with that name is overwritten.
+func SpeechFilename(name string) SpeechFileOption {
+	return SpeechFilenameFunc(func(extension string) string {
+		if filepath.Ext(name) == "" {
+			return name + "." + extension
+		}
+		return name
+	})
+}
+
+// SpeechFilenameFunc names files with fn.
+func SpeechFilenameFunc(fn func(extension string) string) SpeechFileOption {
+	return func(f *SpeechFile) {
+		f.Filename = fn
+	}
+}
+
+// NewSpeechFile applies options on top of the default policy,
+// a "speech_<uuid>.<extension>" file in os.TempDir().
+func NewSpeechFile(options ...SpeechFileOption) SpeechFile {
+	f := SpeechFile{
+		Directory: os.TempDir(),
+		Filename: func(extension string) string {
+			return fmt.Sprintf("speech_%s.%s", uuid.Must(uuid.NewRandom()).String(), extension)
+		},
+	}
+	for _, option := range options {
+		option(&f)
+	}
+	return f
+}
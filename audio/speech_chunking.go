@@ -0,0 +1,201 @@
+package audio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultSpeechMaxChars is the input limit of OpenAI speech models.
+const DefaultSpeechMaxChars = 4096
+
+// SpeechChunkOptions controls how long text is synthesized.
+type SpeechChunkOptions struct {
+	// MaxChars is the input limit of the model. Defaults to DefaultSpeechMaxChars.
+	MaxChars int
+	// Concurrency limits parallel synthesis requests. Defaults to 4.
+	Concurrency int
+}
+
+func (o SpeechChunkOptions) withDefaults() SpeechChunkOptions {
+	if o.MaxChars <= 0 {
+		o.MaxChars = DefaultSpeechMaxChars
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	return o
+}
+
+// SplitSpeech splits text into chunks of at most MaxChars runes.
+// Chunks end at paragraph boundaries when possible, then at sentence ends,
+// then between words. Whitespace inside paragraphs is normalized.
+func (o SpeechChunkOptions) SplitSpeech(text string) []string {
+	maxChars := o.withDefaults().MaxChars
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+
+	chunks := make([]string, 0)
+	current := ""
+	add := func(piece, sep string) {
+		switch {
+		case current == "":
+			current = piece
+		case utf8.RuneCountInString(current)+utf8.RuneCountInString(sep)+utf8.RuneCountInString(piece) <= maxChars:
+			current += sep + piece
+		default:
+			chunks = append(chunks, current)
+			current = piece
+		}
+	}
+
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		sep := "\n\n"
+		for _, sentence := range splitSentences(paragraph) {
+			for _, part := range splitText(sentence, maxChars) {
+				for _, piece := range splitRunes(part, maxChars) {
+					add(piece, sep)
+					sep = " "
+				}
+			}
+		}
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// splitSentences splits after words ending with ., ! or ?, ignoring closing quotes and brackets.
+func splitSentences(paragraph string) []string {
+	sentences := make([]string, 0)
+	words := make([]string, 0)
+	for _, word := range strings.Fields(paragraph) {
+		words = append(words, word)
+		end := strings.TrimRight(word, `"')]»”’`)
+		if strings.HasSuffix(end, ".") || strings.HasSuffix(end, "!") || strings.HasSuffix(end, "?") || strings.HasSuffix(end, "…") {
+			sentences = append(sentences, strings.Join(words, " "))
+			words = words[:0]
+		}
+	}
+	if len(words) > 0 {
+		sentences = append(sentences, strings.Join(words, " "))
+	}
+	return sentences
+}
+
+// splitRunes cuts a single word longer than limit.
+func splitRunes(word string, limit int) []string {
+	runes := []rune(word)
+	if len(runes) <= limit {
+		return []string{word}
+	}
+	parts := make([]string, 0, len(runes)/limit+1)
+	for len(runes) > limit {
+		parts = append(parts, string(runes[:limit]))
+		runes = runes[limit:]
+	}
+	return append(parts, string(runes))
+}
+
+// ConcatSpeech joins synthesized chunks of one response_format into w.
+// wav chunks get one header with the total data size, pcm, mp3 and aac
+// frames are concatenated (ID3 tags of mp3 chunks are dropped after the first chunk).
+func ConcatSpeech(w io.Writer, format string, parts [][]byte) error {
+	switch len(parts) {
+	case 0:
+		return nil
+	case 1:
+		if _, err := w.Write(parts[0]); err != nil {
+			return fmt.Errorf("failed to write %s audio: %w", format, err)
+		}
+		return nil
+	}
+	if err := CheckConcatFormat(format); err != nil {
+		return err
+	}
+
+	switch format {
+	case "wav":
+		return concatWAV(w, parts)
+	case "mp3":
+		for i, part := range parts {
+			if i > 0 {
+				part = stripID3v2(part)
+			}
+			if i < len(parts)-1 {
+				part = stripID3v1(part)
+			}
+			if _, err := w.Write(part); err != nil {
+				return fmt.Errorf("failed to write mp3 audio: %w", err)
+			}
+		}
+		return nil
+	}
+
+	for _, part := range parts {
+		if _, err := w.Write(part); err != nil {
+			return fmt.Errorf("failed to write %s audio: %w", format, err)
+		}
+	}
+	return nil
+}
+
+// CheckConcatFormat reports whether chunks of a speech response_format can be joined by ConcatSpeech.
+func CheckConcatFormat(format string) error {
+	switch format {
+	case "wav", "pcm", "mp3", "aac":
+		return nil
+	}
+	return fmt.Errorf("cannot concatenate %q audio, use wav, pcm, mp3 or aac", format)
+}
+
+func concatWAV(w io.Writer, parts [][]byte) error {
+	wavs := make([]*WAV, 0, len(parts))
+	var dataSize int64
+	for i, part := range parts {
+		wav, err := ReadWAV(bytes.NewReader(part), int64(len(part)))
+		if err != nil {
+			return fmt.Errorf("failed to read wav chunk %d: %w", i, err)
+		}
+		if len(wavs) > 0 && wav.Format != wavs[0].Format {
+			return fmt.Errorf("wav chunk %d format %+v differs from %+v", i, wav.Format, wavs[0].Format)
+		}
+		wavs = append(wavs, wav)
+		dataSize += wav.dataSize
+	}
+
+	if _, err := w.Write(wavs[0].header(dataSize)); err != nil {
+		return fmt.Errorf("failed to write wav header: %w", err)
+	}
+	for _, wav := range wavs {
+		if _, err := io.Copy(w, io.NewSectionReader(wav.data, wav.dataOffset, wav.dataSize)); err != nil {
+			return fmt.Errorf("failed to write wav data: %w", err)
+		}
+	}
+	return nil
+}
+
+// stripID3v2 removes a leading ID3v2 tag.
+func stripID3v2(data []byte) []byte {
+	if len(data) < 10 || string(data[0:3]) != "ID3" {
+		return data
+	}
+	// tag size is a 28 bit syncsafe integer without the 10 byte header
+	size := int(data[6]&0x7f)<<21 | int(data[7]&0x7f)<<14 | int(data[8]&0x7f)<<7 | int(data[9]&0x7f)
+	size += 10
+	if data[5]&0x10 != 0 {
+		size += 10 // footer
+	}
+	return data[min(size, len(data)):]
+}
+
+// stripID3v1 removes a trailing 128 byte ID3v1 tag.
+func stripID3v1(data []byte) []byte {
+	if len(data) >= 128 && string(data[len(data)-128:len(data)-125]) == "TAG" {
+		return data[:len(data)-128]
+	}
+	return data
+}
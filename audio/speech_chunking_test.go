@@ -0,0 +1,126 @@
+package audio_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+)
+
+func TestSpeechChunkOptions_SplitSpeech(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxChars int
+		text     string
+		want     []string
+	}{
+		{
+			name:     "fits",
+			maxChars: 100,
+			text:     "  Hello there.\n\nGeneral   Kenobi!  ",
+			want:     []string{"Hello there.\n\nGeneral Kenobi!"},
+		},
+		{
+			name:     "paragraphs",
+			maxChars: 30,
+			text:     "First paragraph here.\r\n\r\nSecond one.\n\nThird one.",
+			want:     []string{"First paragraph here.", "Second one.\n\nThird one."},
+		},
+		{
+			name:     "sentences",
+			maxChars: 25,
+			text:     `He said "stop." Then he left! Did she follow? Nobody knows`,
+			want:     []string{`He said "stop."`, "Then he left!", "Did she follow?", "Nobody knows"},
+		},
+		{
+			name:     "long words",
+			maxChars: 4,
+			text:     "ab cdefghij",
+			want:     []string{"ab", "cdef", "ghij"},
+		},
+		{
+			name:     "runes",
+			maxChars: 6,
+			text:     "Größe über alles.",
+			want:     []string{"Größe", "über", "alles."},
+		},
+		{
+			name:     "empty",
+			maxChars: 10,
+			text:     " \n\n ",
+			want:     []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := audio.SpeechChunkOptions{MaxChars: tt.maxChars}.SplitSpeech(tt.text)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	long := strings.Repeat("A short sentence. ", 1000)
+	for _, chunk := range (audio.SpeechChunkOptions{}).SplitSpeech(long) {
+		assert.LessOrEqual(t, len(chunk), audio.DefaultSpeechMaxChars)
+		assert.True(t, strings.HasSuffix(chunk, "."))
+	}
+}
+
+func TestConcatSpeech(t *testing.T) {
+	t.Run("wav", func(t *testing.T) {
+		first := testPCM([]time.Duration{time.Second}, []bool{true})
+		second := testPCM([]time.Duration{500 * time.Millisecond}, []bool{false})
+
+		var out bytes.Buffer
+		require.NoError(t, audio.ConcatSpeech(&out, "wav", [][]byte{testWAV(first), testWAV(second)}))
+
+		wav, err := audio.ReadWAV(bytes.NewReader(out.Bytes()), int64(out.Len()))
+		require.NoError(t, err)
+		assert.Equal(t, 1500*time.Millisecond, wav.Duration())
+		assert.Equal(t, 44+len(first)+len(second), out.Len())
+		assert.Equal(t, append(first, second...), out.Bytes()[44:])
+	})
+
+	t.Run("wav format mismatch", func(t *testing.T) {
+		stereo := testWAV(testPCM([]time.Duration{time.Second}, []bool{true}))
+		stereo[22] = 2
+
+		err := audio.ConcatSpeech(&bytes.Buffer{}, "wav", [][]byte{testWAV(testPCM([]time.Duration{time.Second}, []bool{true})), stereo})
+		assert.ErrorContains(t, err, "wav chunk 1 format")
+	})
+
+	t.Run("mp3", func(t *testing.T) {
+		id3v2 := append([]byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 2}, 'x', 'x')
+		id3v1 := append([]byte("TAG"), make([]byte, 125)...)
+		part := func(frames string) []byte {
+			data := append([]byte{}, id3v2...)
+			data = append(data, frames...)
+			return append(data, id3v1...)
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, audio.ConcatSpeech(&out, "mp3", [][]byte{part("one"), part("two")}))
+		want := append(append([]byte{}, id3v2...), "onetwo"...)
+		assert.Equal(t, append(want, id3v1...), out.Bytes())
+	})
+
+	t.Run("pcm and single part", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, audio.ConcatSpeech(&out, "pcm", [][]byte{[]byte("ab"), []byte("cd")}))
+		assert.Equal(t, "abcd", out.String())
+
+		out.Reset()
+		require.NoError(t, audio.ConcatSpeech(&out, "opus", [][]byte{[]byte("ogg")}))
+		assert.Equal(t, "ogg", out.String())
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		err := audio.ConcatSpeech(&bytes.Buffer{}, "opus", [][]byte{[]byte("a"), []byte("b")})
+		assert.ErrorContains(t, err, `cannot concatenate "opus" audio`)
+	})
+}
@@ -0,0 +1,82 @@
+package audio_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+)
+
+func speechDelta(data string) string {
+	return fmt.Sprintf(`{"type":"speech.audio.delta","audio":%q}`, base64.StdEncoding.EncodeToString([]byte(data)))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestDecodeSpeechEvents(t *testing.T) {
+	t.Run("deltas", func(t *testing.T) {
+		stream := ": keep-alive\n\n" +
+			"event: delta\ndata: " + speechDelta("hello ") + "\n\n" +
+			"data: " + speechDelta("world") + "\n\n" +
+			`data: {"type":"speech.audio.done","usage":{"input_tokens":3}}` + "\n\n" +
+			"data: " + speechDelta("ignored") + "\n\n"
+
+		var out bytes.Buffer
+		n, err := audio.DecodeSpeechEvents(strings.NewReader(stream), &out)
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), n)
+		assert.Equal(t, "hello world", out.String())
+	})
+
+	t.Run("unterminated last event", func(t *testing.T) {
+		var out bytes.Buffer
+		n, err := audio.DecodeSpeechEvents(strings.NewReader("data: "+speechDelta("abc")), &out)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := audio.DecodeSpeechEvents(strings.NewReader(`data: {"type":"error","error":{"message":"quota"}}`+"\n\n"), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "speech stream error: quota")
+
+		_, err = audio.DecodeSpeechEvents(strings.NewReader(`data: {"audio":"***"}`+"\n\n"), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "failed to decode speech audio delta")
+
+		_, err = audio.DecodeSpeechEvents(strings.NewReader("data: not json\n\n"), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "failed to parse speech event")
+
+		_, err = audio.DecodeSpeechEvents(strings.NewReader("data: "+speechDelta("abc")+"\n\n"), failingWriter{})
+		assert.ErrorContains(t, err, "disk full")
+	})
+}
+
+func TestNewSpeechFile(t *testing.T) {
+	f := audio.NewSpeechFile()
+	assert.Equal(t, os.TempDir(), f.Directory)
+	name := f.Filename("wav")
+	assert.True(t, strings.HasPrefix(name, "speech_"))
+	assert.True(t, strings.HasSuffix(name, ".wav"))
+	assert.NotEqual(t, name, f.Filename("wav"))
+
+	f = audio.NewSpeechFile(audio.SpeechDirectory("/out"), audio.SpeechFilename("greeting"))
+	assert.Equal(t, "/out", f.Directory)
+	assert.Equal(t, "greeting.mp3", f.Filename("mp3"))
+
+	f = audio.NewSpeechFile(audio.SpeechFilename("greeting.opus"))
+	assert.Equal(t, "greeting.opus", f.Filename("mp3"))
+
+	f = audio.NewSpeechFile(audio.SpeechFilenameFunc(func(extension string) string { return "tts." + extension }))
+	assert.Equal(t, "tts.flac", f.Filename("flac"))
+}
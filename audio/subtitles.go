@@ -0,0 +1,209 @@
+package audio
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SubtitleOptions controls how transcriptions are turned into subtitle cues.
+type SubtitleOptions struct {
+	// MaxLineLength wraps cue text at word boundaries. Defaults to 42.
+	MaxLineLength int
+	// MaxCueChars splits longer cues into several cues. Defaults to 2*MaxLineLength.
+	MaxCueChars int
+	// MaxCueDuration starts a new cue when building cues from words. Defaults to 7 seconds.
+	MaxCueDuration time.Duration
+	// UseWords builds cues from word timestamps instead of segments when words are available.
+	UseWords bool
+}
+
+func (o SubtitleOptions) withDefaults() SubtitleOptions {
+	if o.MaxLineLength <= 0 {
+		o.MaxLineLength = 42
+	}
+	if o.MaxCueChars <= 0 {
+		o.MaxCueChars = 2 * o.MaxLineLength
+	}
+	if o.MaxCueDuration <= 0 {
+		o.MaxCueDuration = 7 * time.Second
+	}
+	return o
+}
+
+// Cues returns the subtitle cues of the transcription.
+// Without segments or words the whole text becomes one cue spanning Duration.
+func (r AudioResponse) Cues(options SubtitleOptions) []Segment {
+	options = options.withDefaults()
+	if options.UseWords && len(r.Words) > 0 {
+		return cuesFromWords(r.Words, options)
+	}
+	if len(r.Segments) > 0 {
+		return SplitSegments(r.Segments, options)
+	}
+	if strings.TrimSpace(r.Text) == "" {
+		return []Segment{}
+	}
+	return SplitSegments([]Segment{{Start: 0, End: r.Duration, Text: r.Text}}, options)
+}
+
+// SRT renders the transcription as SubRip subtitles.
+func (r AudioResponse) SRT(options SubtitleOptions) string {
+	var b strings.Builder
+	_ = WriteSRT(&b, r.Cues(options), options)
+	return b.String()
+}
+
+// VTT renders the transcription as WebVTT subtitles.
+func (r AudioResponse) VTT(options SubtitleOptions) string {
+	var b strings.Builder
+	_ = WriteVTT(&b, r.Cues(options), options)
+	return b.String()
+}
+
+// WriteSRT writes cues as SubRip subtitles. Cue text is wrapped at MaxLineLength.
+func WriteSRT(w io.Writer, cues []Segment, options SubtitleOptions) error {
+	return writeSubtitles(w, "", cues, options, func(i int, cue Segment) string {
+		return fmt.Sprintf("%d\n%s --> %s\n", i+1, formatTimestamp(cue.Start, ","), formatTimestamp(cue.End, ","))
+	})
+}
+
+// WriteVTT writes cues as WebVTT subtitles. Cue text is wrapped at MaxLineLength.
+func WriteVTT(w io.Writer, cues []Segment, options SubtitleOptions) error {
+	return writeSubtitles(w, "WEBVTT\n\n", cues, options, func(_ int, cue Segment) string {
+		return fmt.Sprintf("%s --> %s\n", formatTimestamp(cue.Start, "."), formatTimestamp(cue.End, "."))
+	})
+}
+
+func writeSubtitles(w io.Writer, header string, cues []Segment, options SubtitleOptions, timing func(int, Segment) string) error {
+	options = options.withDefaults()
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(header); err != nil {
+		return fmt.Errorf("failed to write subtitles: %w", err)
+	}
+	for i, cue := range cues {
+		if _, err := bw.WriteString(timing(i, cue) + wrapLines(cue.Text, options.MaxLineLength) + "\n\n"); err != nil {
+			return fmt.Errorf("failed to write subtitles: %w", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write subtitles: %w", err)
+	}
+	return nil
+}
+
+// SplitSegments splits segments longer than MaxCueChars at word boundaries.
+// The time of a split segment is shared proportionally to the text length.
+// Returned cues are numbered from 0.
+func SplitSegments(segments []Segment, options SubtitleOptions) []Segment {
+	options = options.withDefaults()
+	cues := make([]Segment, 0, len(segments))
+	for _, segment := range segments {
+		parts := splitText(segment.Text, options.MaxCueChars)
+		total := 0
+		for _, part := range parts {
+			total += utf8.RuneCountInString(part)
+		}
+
+		start, done := segment.Start, 0
+		for _, part := range parts {
+			done += utf8.RuneCountInString(part)
+			end := segment.End
+			if done < total {
+				end = segment.Start + (segment.End-segment.Start)*float32(done)/float32(total)
+			}
+			cues = append(cues, Segment{ID: len(cues), Start: start, End: end, Text: part})
+			start = end
+		}
+	}
+	return cues
+}
+
+// ShiftSegments returns a copy of segments moved by offset seconds. Times are clamped at 0.
+func ShiftSegments(segments []Segment, offset float32) []Segment {
+	shifted := make([]Segment, len(segments))
+	for i, segment := range segments {
+		segment.Start = max(0, segment.Start+offset)
+		segment.End = max(0, segment.End+offset)
+		shifted[i] = segment
+	}
+	return shifted
+}
+
+// cuesFromWords groups words into cues limited by MaxCueChars and MaxCueDuration.
+// A cue also ends after a word that ends a sentence.
+func cuesFromWords(words []Word, options SubtitleOptions) []Segment {
+	maxDuration := float32(options.MaxCueDuration.Seconds())
+	cues := make([]Segment, 0)
+	var current []string
+	var cue Segment
+
+	flush := func() {
+		if len(current) > 0 {
+			cue.ID = len(cues)
+			cue.Text = strings.Join(current, " ")
+			cues = append(cues, cue)
+			current = nil
+		}
+	}
+
+	for _, word := range words {
+		text := strings.TrimSpace(word.Word)
+		if text == "" {
+			continue
+		}
+		if len(current) > 0 {
+			length := utf8.RuneCountInString(strings.Join(current, " ")) + 1 + utf8.RuneCountInString(text)
+			if length > options.MaxCueChars || word.End-cue.Start > maxDuration {
+				flush()
+			}
+		}
+		if len(current) == 0 {
+			cue = Segment{Start: word.Start}
+		}
+		current = append(current, text)
+		cue.End = word.End
+		if strings.ContainsAny(text[len(text)-1:], ".?!") {
+			flush()
+		}
+	}
+	flush()
+
+	return cues
+}
+
+// splitText splits text into parts of at most limit runes at word boundaries.
+// Words longer than limit become their own part.
+func splitText(text string, limit int) []string {
+	parts := make([]string, 0, 1)
+	current := ""
+	for _, word := range strings.Fields(text) {
+		if current != "" && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > limit {
+			parts = append(parts, current)
+			current = ""
+		}
+		if current == "" {
+			current = word
+		} else {
+			current += " " + word
+		}
+	}
+	if current != "" {
+		parts = append(parts, current)
+	}
+	return parts
+}
+
+func wrapLines(text string, maxLineLength int) string {
+	return strings.Join(splitText(text, maxLineLength), "\n")
+}
+
+// formatTimestamp formats seconds as "hh:mm:ss<sep>mmm".
+func formatTimestamp(seconds float32, sep string) string {
+	ms := int64(math.Round(float64(max(0, seconds)) * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
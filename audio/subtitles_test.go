@@ -0,0 +1,96 @@
+package audio_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+)
+
+func TestAudioResponse_SRT(t *testing.T) {
+	res := audio.AudioResponse{
+		Segments: []audio.Segment{
+			{Start: 0, End: 2.5, Text: " Hello there."},
+			{Start: 2.5, End: 3661.002, Text: " General Kenobi, you are a bold one."},
+		},
+	}
+
+	srt := res.SRT(audio.SubtitleOptions{MaxLineLength: 20})
+	assert.Equal(t, "1\n00:00:00,000 --> 00:00:02,500\nHello there.\n\n"+
+		"2\n00:00:02,500 --> 01:01:01,002\nGeneral Kenobi, you\nare a bold one.\n\n", srt)
+}
+
+func TestAudioResponse_VTT(t *testing.T) {
+	res := audio.AudioResponse{Text: "one two three four", Duration: 4}
+
+	vtt := res.VTT(audio.SubtitleOptions{MaxLineLength: 5, MaxCueChars: 9})
+	assert.Equal(t, "WEBVTT\n\n"+
+		"00:00:00.000 --> 00:00:01.750\none\ntwo\n\n"+
+		"00:00:01.750 --> 00:00:03.000\nthree\n\n"+
+		"00:00:03.000 --> 00:00:04.000\nfour\n\n", vtt)
+
+	assert.Equal(t, "WEBVTT\n\n", audio.AudioResponse{}.VTT(audio.SubtitleOptions{}))
+}
+
+func TestAudioResponse_Cues_Words(t *testing.T) {
+	res := audio.AudioResponse{
+		Segments: []audio.Segment{{Start: 0, End: 10, Text: "ignored when words are used"}},
+		Words: []audio.Word{
+			{Word: "Hi.", Start: 0, End: 0.4},
+			{Word: "This", Start: 1, End: 1.2},
+			{Word: "is", Start: 1.2, End: 1.3},
+			{Word: "long", Start: 1.3, End: 1.6},
+			{Word: "speech", Start: 1.6, End: 2},
+			{Word: "without", Start: 5, End: 5.5},
+			{Word: "pauses", Start: 8.5, End: 9},
+		},
+	}
+
+	cues := res.Cues(audio.SubtitleOptions{UseWords: true, MaxCueChars: 20, MaxCueDuration: 3 * time.Second})
+	require.Len(t, cues, 4)
+	assert.Equal(t, audio.Segment{ID: 0, Start: 0, End: 0.4, Text: "Hi."}, cues[0])
+	assert.Equal(t, audio.Segment{ID: 1, Start: 1, End: 2, Text: "This is long speech"}, cues[1])
+	assert.Equal(t, audio.Segment{ID: 2, Start: 5, End: 5.5, Text: "without"}, cues[2])
+	assert.Equal(t, audio.Segment{ID: 3, Start: 8.5, End: 9, Text: "pauses"}, cues[3])
+
+	cues = res.Cues(audio.SubtitleOptions{})
+	require.Len(t, cues, 1)
+	assert.Equal(t, "ignored when words are used", cues[0].Text)
+}
+
+func TestParseSubtitles_RoundTrip(t *testing.T) {
+	cues := []audio.Segment{
+		{ID: 0, Start: 1.25, End: 3, Text: "first cue"},
+		{ID: 1, Start: 3, End: 65.5, Text: "second cue that wraps"},
+	}
+	options := audio.SubtitleOptions{MaxLineLength: 12}
+
+	for name, write := range map[string]func(*bytes.Buffer) error{
+		"srt": func(b *bytes.Buffer) error { return audio.WriteSRT(b, cues, options) },
+		"vtt": func(b *bytes.Buffer) error { return audio.WriteVTT(b, cues, options) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, write(&buf))
+
+			parsed, err := audio.ParseSubtitles(buf.String())
+			require.NoError(t, err)
+			require.Len(t, parsed, 2)
+			assert.Equal(t, cues[0], parsed[0])
+			assert.Equal(t, "second cue\nthat wraps", parsed[1].Text)
+			assert.Equal(t, float32(65.5), parsed[1].End)
+		})
+	}
+}
+
+func TestShiftSegments(t *testing.T) {
+	segments := []audio.Segment{{Start: 1, End: 2, Text: "a"}, {Start: 5, End: 6, Text: "b"}}
+
+	shifted := audio.ShiftSegments(segments, -1.5)
+	assert.Equal(t, []audio.Segment{{Start: 0, End: 0.5, Text: "a"}, {Start: 3.5, End: 4.5, Text: "b"}}, shifted)
+	assert.Equal(t, float32(1), segments[0].Start)
+}
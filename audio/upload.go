@@ -0,0 +1,62 @@
+package audio
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ProgressFunc is called while an upload is streamed. total is -1 when the size is unknown.
+type ProgressFunc func(sent, total int64)
+
+// Upload is audio read from any io.Reader, e.g. object storage or a microphone buffer.
+type Upload struct {
+	Reader io.Reader
+	// Filename is sent with the multipart file; providers use its extension to detect the format.
+	Filename string
+	// Size is optional and only used as the progress total. Use -1 or 0 when unknown.
+	Size int64
+	// Progress is optional.
+	Progress ProgressFunc
+}
+
+// OpenUpload opens a local audio file as an Upload. Close the returned file after the request.
+func OpenUpload(filePath string) (Upload, io.Closer, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return Upload{}, nil, fmt.Errorf("error opening file: %w", err)
+	}
+	upload := Upload{Reader: file, Filename: filepath.Base(filePath), Size: -1}
+	if info, err := file.Stat(); err == nil {
+		upload.Size = info.Size()
+	}
+	return upload, file, nil
+}
+
+func (u Upload) reader() io.Reader {
+	if u.Progress == nil {
+		return u.Reader
+	}
+	total := u.Size
+	if total <= 0 {
+		total = -1
+	}
+	return &progressReader{reader: u.Reader, total: total, progress: u.Progress}
+}
+
+type progressReader struct {
+	reader   io.Reader
+	sent     int64
+	total    int64
+	progress ProgressFunc
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+	n, err := p.reader.Read(buf)
+	if n > 0 {
+		p.sent += int64(n)
+		p.progress(p.sent, p.total)
+	}
+	return n, err
+}
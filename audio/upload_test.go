@@ -0,0 +1,113 @@
+package audio_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+)
+
+func TestTranscribeUpload_StreamsReader(t *testing.T) {
+	payload := bytes.Repeat([]byte("pcm-data"), 64*1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// streamed bodies are sent chunked without a content length
+		assert.Equal(t, int64(-1), r.ContentLength)
+
+		err := r.ParseMultipartForm(1 << 20)
+		assert.NoError(t, err)
+		assert.Equal(t, "whisper-1", r.FormValue("model"))
+		assert.Equal(t, "en", r.FormValue("language"))
+
+		file, header, err := r.FormFile("file")
+		assert.NoError(t, err)
+		defer file.Close()
+		assert.Equal(t, "mic.wav", header.Filename)
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, payload, data)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var lastSent, lastTotal int64
+	calls := 0
+	upload := audio.Upload{
+		Reader:   bytes.NewReader(payload),
+		Filename: "mic.wav",
+		Size:     int64(len(payload)),
+		Progress: func(sent, total int64) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.GreaterOrEqual(t, sent, lastSent)
+			lastSent, lastTotal = sent, total
+			calls++
+		},
+	}
+
+	resp, err := audio.TranscribeUpload(context.Background(), server.URL, "test-token", upload, "whisper-1", map[string]any{"language": "en"}, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, int64(len(payload)), lastSent)
+	assert.Equal(t, int64(len(payload)), lastTotal)
+	assert.Greater(t, calls, 1)
+}
+
+func TestTranscribeUpload_UnknownSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var total int64
+	upload := audio.Upload{
+		Reader:   strings.NewReader("abc"),
+		Filename: "a.wav",
+		Progress: func(_, t int64) { total = t },
+	}
+	resp, err := audio.TranscribeUpload(context.Background(), server.URL, "test-token", upload, "whisper-1", nil, nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, int64(-1), total)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func TestTranscribeUpload_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := audio.TranscribeUpload(context.Background(), server.URL, "token", audio.Upload{Filename: "a.wav"}, "whisper-1", nil, nil)
+	assert.ErrorContains(t, err, "reader cannot be nil")
+
+	_, err = audio.TranscribeUpload(context.Background(), server.URL, "token", audio.Upload{Reader: strings.NewReader("a")}, "whisper-1", nil, nil)
+	assert.ErrorContains(t, err, "filename cannot be empty")
+
+	_, err = audio.TranscribeUpload(context.Background(), server.URL, "token", audio.Upload{Reader: failingReader{}, Filename: "a.wav"}, "whisper-1", nil, nil)
+	assert.ErrorContains(t, err, "error copying file content")
+
+	_, err = audio.TranscribeUpload(context.Background(), server.URL, "token", audio.Upload{Reader: strings.NewReader("a"), Filename: "a.wav"}, "whisper-1", map[string]any{"bad": func() {}}, nil)
+	assert.ErrorContains(t, err, "error marshaling extra body field bad")
+}
@@ -0,0 +1,221 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const (
+	WAV_FORMAT_PCM        uint16 = 1
+	WAV_FORMAT_FLOAT      uint16 = 3
+	WAV_FORMAT_EXTENSIBLE uint16 = 0xFFFE
+
+	wavHeaderSize = 44
+)
+
+// WAVFormat describes interleaved PCM frames.
+type WAVFormat struct {
+	AudioFormat   uint16 // WAV_FORMAT_PCM or WAV_FORMAT_FLOAT
+	Channels      uint16
+	SampleRate    uint32
+	BitsPerSample uint16
+}
+
+func (f WAVFormat) blockAlign() int64 {
+	return int64(f.Channels) * int64(f.BitsPerSample/8)
+}
+
+func (f WAVFormat) validate() error {
+	if f.Channels == 0 || f.SampleRate == 0 {
+		return fmt.Errorf("wav format needs channels and sample rate")
+	}
+	switch {
+	case f.AudioFormat == WAV_FORMAT_PCM && (f.BitsPerSample == 8 || f.BitsPerSample == 16 || f.BitsPerSample == 24 || f.BitsPerSample == 32):
+	case f.AudioFormat == WAV_FORMAT_FLOAT && f.BitsPerSample == 32:
+	default:
+		return fmt.Errorf("unsupported wav format %d with %d bits per sample", f.AudioFormat, f.BitsPerSample)
+	}
+	return nil
+}
+
+// WAV gives random access to PCM frames stored in a WAV file or a raw PCM stream.
+// Frames are read on demand, the audio is never loaded into memory as a whole.
+type WAV struct {
+	Format     WAVFormat
+	data       io.ReaderAt
+	dataOffset int64
+	dataSize   int64
+}
+
+// IsWAV reports whether head starts with a RIFF/WAVE header.
+func IsWAV(head []byte) bool {
+	return len(head) >= 12 && string(head[0:4]) == "RIFF" && string(head[8:12]) == "WAVE"
+}
+
+// ReadWAV parses the WAV header of r. size is the total size of r.
+func ReadWAV(r io.ReaderAt, size int64) (*WAV, error) {
+	head := make([]byte, 12)
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return nil, fmt.Errorf("failed to read wav header: %w", err)
+	}
+	if !IsWAV(head) {
+		return nil, fmt.Errorf("not a RIFF/WAVE file")
+	}
+
+	wav := &WAV{data: r}
+	foundFormat := false
+	offset := int64(12)
+	chunkHeader := make([]byte, 8)
+	for offset+8 <= size {
+		if _, err := r.ReadAt(chunkHeader, offset); err != nil {
+			return nil, fmt.Errorf("failed to read wav chunk: %w", err)
+		}
+		chunkID := string(chunkHeader[0:4])
+		chunkSize := int64(binary.LittleEndian.Uint32(chunkHeader[4:8]))
+		offset += 8
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize < 16 {
+				return nil, fmt.Errorf("wav fmt chunk too small")
+			}
+			fmtChunk := make([]byte, min(chunkSize, 40))
+			if _, err := r.ReadAt(fmtChunk, offset); err != nil {
+				return nil, fmt.Errorf("failed to read wav fmt chunk: %w", err)
+			}
+			wav.Format = WAVFormat{
+				AudioFormat:   binary.LittleEndian.Uint16(fmtChunk[0:2]),
+				Channels:      binary.LittleEndian.Uint16(fmtChunk[2:4]),
+				SampleRate:    binary.LittleEndian.Uint32(fmtChunk[4:8]),
+				BitsPerSample: binary.LittleEndian.Uint16(fmtChunk[14:16]),
+			}
+			// the real format of WAVE_FORMAT_EXTENSIBLE is the start of the sub format GUID
+			if wav.Format.AudioFormat == WAV_FORMAT_EXTENSIBLE && len(fmtChunk) >= 26 {
+				wav.Format.AudioFormat = binary.LittleEndian.Uint16(fmtChunk[24:26])
+			}
+			foundFormat = true
+		case "data":
+			if !foundFormat {
+				return nil, fmt.Errorf("wav data chunk before fmt chunk")
+			}
+			if err := wav.Format.validate(); err != nil {
+				return nil, err
+			}
+			// streamed wav files may carry a placeholder size
+			wav.dataOffset = offset
+			wav.dataSize = min(chunkSize, size-offset)
+			wav.dataSize -= wav.dataSize % wav.Format.blockAlign()
+			return wav, nil
+		}
+
+		offset += chunkSize + chunkSize%2
+	}
+
+	return nil, fmt.Errorf("wav data chunk not found")
+}
+
+// NewPCM wraps raw interleaved PCM frames without a header.
+func NewPCM(r io.ReaderAt, size int64, format WAVFormat) (*WAV, error) {
+	if err := format.validate(); err != nil {
+		return nil, err
+	}
+	return &WAV{Format: format, data: r, dataSize: size - size%format.blockAlign()}, nil
+}
+
+// Duration returns the playback length.
+func (w *WAV) Duration() time.Duration {
+	return w.frameTime(w.frames())
+}
+
+func (w *WAV) frames() int64 {
+	return w.dataSize / w.Format.blockAlign()
+}
+
+func (w *WAV) frameTime(frame int64) time.Duration {
+	return time.Duration(frame * int64(time.Second) / int64(w.Format.SampleRate))
+}
+
+func (w *WAV) frameAt(t time.Duration) int64 {
+	frame := int64(t) * int64(w.Format.SampleRate) / int64(time.Second)
+	return max(0, min(frame, w.frames()))
+}
+
+// Section returns a standalone WAV file with the audio between start and end, and its size.
+func (w *WAV) Section(start, end time.Duration) (io.Reader, int64) {
+	from, to := w.frameAt(start), w.frameAt(end)
+	if to < from {
+		to = from
+	}
+	align := w.Format.blockAlign()
+	dataSize := (to - from) * align
+
+	header := w.header(dataSize)
+	data := io.NewSectionReader(w.data, w.dataOffset+from*align, dataSize)
+	return io.MultiReader(bytes.NewReader(header), data), int64(len(header)) + dataSize
+}
+
+func (w *WAV) header(dataSize int64) []byte {
+	f := w.Format
+	buf := make([]byte, wavHeaderSize)
+	copy(buf[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(wavHeaderSize-8+dataSize))
+	copy(buf[8:12], "WAVE")
+	copy(buf[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(buf[16:20], 16)
+	binary.LittleEndian.PutUint16(buf[20:22], f.AudioFormat)
+	binary.LittleEndian.PutUint16(buf[22:24], f.Channels)
+	binary.LittleEndian.PutUint32(buf[24:28], f.SampleRate)
+	binary.LittleEndian.PutUint32(buf[28:32], f.SampleRate*uint32(f.blockAlign()))
+	binary.LittleEndian.PutUint16(buf[32:34], uint16(f.blockAlign()))
+	binary.LittleEndian.PutUint16(buf[34:36], f.BitsPerSample)
+	copy(buf[36:40], "data")
+	binary.LittleEndian.PutUint32(buf[40:44], uint32(dataSize))
+	return buf
+}
+
+// levels returns the RMS level (0..1) of consecutive windows between start and end.
+func (w *WAV) levels(start, end, window time.Duration) ([]float64, error) {
+	from, to := w.frameAt(start), w.frameAt(end)
+	windowFrames := max(1, w.frameAt(window))
+	align := w.Format.blockAlign()
+	bytesPerSample := int64(w.Format.BitsPerSample / 8)
+
+	levels := make([]float64, 0, (to-from)/windowFrames+1)
+	buf := make([]byte, windowFrames*align)
+	for frame := from; frame < to; frame += windowFrames {
+		n := min(windowFrames, to-frame) * align
+		if _, err := w.data.ReadAt(buf[:n], w.dataOffset+frame*align); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read wav samples: %w", err)
+		}
+
+		sum, count := 0.0, 0
+		for i := int64(0); i+bytesPerSample <= n; i += bytesPerSample {
+			v := w.sample(buf[i : i+bytesPerSample])
+			sum += v * v
+			count++
+		}
+		levels = append(levels, math.Sqrt(sum/float64(max(count, 1))))
+	}
+	return levels, nil
+}
+
+// sample decodes one little endian sample into -1..1.
+func (w *WAV) sample(b []byte) float64 {
+	switch w.Format.BitsPerSample {
+	case 8:
+		return (float64(b[0]) - 128) / 128
+	case 16:
+		return float64(int16(binary.LittleEndian.Uint16(b))) / 32768
+	case 24:
+		v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+		return float64(v) / 8388608
+	}
+	if w.Format.AudioFormat == WAV_FORMAT_FLOAT {
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	}
+	return float64(int32(binary.LittleEndian.Uint32(b))) / 2147483648
+}
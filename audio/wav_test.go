@@ -0,0 +1,98 @@
+package audio_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+)
+
+const testSampleRate = 8000
+
+// testPCM returns 16-bit mono samples: a tone for every true part and silence for false parts.
+func testPCM(parts []time.Duration, tone []bool) []byte {
+	var buf bytes.Buffer
+	for i, part := range parts {
+		frames := int(part.Seconds() * testSampleRate)
+		for f := 0; f < frames; f++ {
+			v := int16(0)
+			if tone[i] {
+				v = int16(16000 * math.Sin(2*math.Pi*440*float64(f)/testSampleRate))
+			}
+			_ = binary.Write(&buf, binary.LittleEndian, v)
+		}
+	}
+	return buf.Bytes()
+}
+
+func testWAV(pcm []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(36+len(pcm)+10))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, struct {
+		Size          uint32
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}{16, 1, 1, testSampleRate, testSampleRate * 2, 2, 16})
+	// unrelated chunk with odd size to check padding
+	buf.WriteString("LIST")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(1))
+	buf.Write([]byte{0, 0})
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(len(pcm)))
+	buf.Write(pcm)
+	return buf.Bytes()
+}
+
+func TestReadWAV(t *testing.T) {
+	pcm := testPCM([]time.Duration{2 * time.Second}, []bool{true})
+	data := testWAV(pcm)
+
+	wav, err := audio.ReadWAV(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, audio.WAVFormat{AudioFormat: audio.WAV_FORMAT_PCM, Channels: 1, SampleRate: testSampleRate, BitsPerSample: 16}, wav.Format)
+	assert.Equal(t, 2*time.Second, wav.Duration())
+
+	section, size := wav.Section(500*time.Millisecond, 1500*time.Millisecond)
+	sectionData, err := io.ReadAll(section)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(sectionData)), size)
+	assert.True(t, audio.IsWAV(sectionData))
+
+	sectionWAV, err := audio.ReadWAV(bytes.NewReader(sectionData), size)
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, sectionWAV.Duration())
+	assert.Equal(t, pcm[testSampleRate:3*testSampleRate], sectionData[44:])
+}
+
+func TestReadWAV_Errors(t *testing.T) {
+	_, err := audio.ReadWAV(bytes.NewReader([]byte("ID3 mp3 data here")), 17)
+	assert.ErrorContains(t, err, "not a RIFF/WAVE file")
+
+	data := testWAV(nil)[:36]
+	_, err = audio.ReadWAV(bytes.NewReader(data), int64(len(data)))
+	assert.ErrorContains(t, err, "data chunk not found")
+
+	_, err = audio.NewPCM(bytes.NewReader(nil), 0, audio.WAVFormat{AudioFormat: audio.WAV_FORMAT_PCM, Channels: 1, SampleRate: 8000, BitsPerSample: 12})
+	assert.ErrorContains(t, err, "unsupported wav format")
+}
+
+func TestNewPCM(t *testing.T) {
+	pcm := testPCM([]time.Duration{3 * time.Second}, []bool{false})
+	wav, err := audio.NewPCM(bytes.NewReader(pcm), int64(len(pcm)), audio.WAVFormat{AudioFormat: audio.WAV_FORMAT_PCM, Channels: 1, SampleRate: testSampleRate, BitsPerSample: 16})
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, wav.Duration())
+}
@@ -0,0 +1,196 @@
+// Package batch reads and writes the OpenAI batch JSONL format. The same files are used
+// by the provider Batch API (client.SubmitBatch) and the local Runner, so jobs can move
+// between both modes.
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// Input is one line of a batch input file.
+type Input struct {
+	CustomID string           `json:"custom_id"`
+	Method   string           `json:"method"`
+	URL      string           `json:"url"`
+	Body     *request.Request `json:"body"`
+}
+
+// NewInputs wraps requests as chat completion inputs with custom ids "request-<index>".
+func NewInputs(requests []*request.Request) []Input {
+	inputs := make([]Input, len(requests))
+	for i, req := range requests {
+		inputs[i] = NewInput(fmt.Sprintf("request-%d", i), req)
+	}
+	return inputs
+}
+
+func NewInput(customID string, req *request.Request) Input {
+	return Input{CustomID: customID, Method: http.MethodPost, URL: request.BATCH_ENDPOINT_CHAT, Body: req}
+}
+
+// WriteInputs writes one JSON line per input. Custom ids must be unique and not empty.
+func WriteInputs(w io.Writer, inputs []Input) error {
+	seen := make(map[string]bool, len(inputs))
+	encoder := json.NewEncoder(w)
+	for i, input := range inputs {
+		if input.CustomID == "" {
+			return fmt.Errorf("input %d has no custom_id", i)
+		}
+		if seen[input.CustomID] {
+			return fmt.Errorf("duplicate custom_id %q", input.CustomID)
+		}
+		seen[input.CustomID] = true
+		if input.Body == nil {
+			return fmt.Errorf("input %q has no body", input.CustomID)
+		}
+		if err := encoder.Encode(input); err != nil {
+			return fmt.Errorf("failed to write batch input %q: %w", input.CustomID, err)
+		}
+	}
+	return nil
+}
+
+// ReadInputs calls fn for every line of a batch input file.
+func ReadInputs(r io.Reader, fn func(Input) error) error {
+	decoder := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var input Input
+		err := decoder.Decode(&input)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read batch input line %d: %w", line, err)
+		}
+		if input.CustomID == "" || input.Body == nil {
+			return fmt.Errorf("batch input line %d needs custom_id and body", line)
+		}
+		if err := fn(input); err != nil {
+			return err
+		}
+	}
+}
+
+// Output is one line of a batch output or error file.
+type Output struct {
+	ID       string          `json:"id,omitempty"`
+	CustomID string          `json:"custom_id"`
+	Response *OutputResponse `json:"response"`
+	Error    *OutputError    `json:"error"`
+}
+
+type OutputResponse struct {
+	StatusCode int             `json:"status_code"`
+	RequestID  string          `json:"request_id,omitempty"`
+	Body       json.RawMessage `json:"body"`
+}
+
+type OutputError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewOutput builds the output line of a finished request, err is written as the line error.
+func NewOutput(customID string, res response.Response, err error) (Output, error) {
+	if err != nil {
+		return Output{CustomID: customID, Error: &OutputError{Message: err.Error()}}, nil
+	}
+	body, err := json.Marshal(res)
+	if err != nil {
+		return Output{}, fmt.Errorf("failed to encode response %q: %w", customID, err)
+	}
+	return Output{CustomID: customID, Response: &OutputResponse{StatusCode: http.StatusOK, RequestID: res.ID, Body: body}}, nil
+}
+
+// Result is a parsed output line. Err is a *ResultError for failed requests.
+type Result struct {
+	CustomID string
+	Response response.Response
+	Err      error
+}
+
+type ResultError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *ResultError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("batch request failed with status %d: %s", e.StatusCode, e.Message)
+	}
+	return "batch request failed: " + e.Message
+}
+
+// Result converts the line to a Result.
+func (o Output) Result() Result {
+	result := Result{CustomID: o.CustomID}
+	switch {
+	case o.Error != nil:
+		result.Err = &ResultError{Code: o.Error.Code, Message: o.Error.Message}
+	case o.Response == nil:
+		result.Err = &ResultError{Message: "output has neither response nor error"}
+	case o.Response.StatusCode != http.StatusOK:
+		var body struct {
+			Error OutputError `json:"error"`
+		}
+		message := string(o.Response.Body)
+		if json.Unmarshal(o.Response.Body, &body) == nil && body.Error.Message != "" {
+			message = body.Error.Message
+		}
+		result.Err = &ResultError{StatusCode: o.Response.StatusCode, Code: body.Error.Code, Message: message}
+	default:
+		if err := json.Unmarshal(o.Response.Body, &result.Response); err != nil {
+			result.Err = &ResultError{StatusCode: o.Response.StatusCode, Message: fmt.Sprintf("invalid response body: %v", err)}
+		}
+	}
+	return result
+}
+
+// WriteOutput appends one output line.
+func WriteOutput(w io.Writer, output Output) error {
+	if err := json.NewEncoder(w).Encode(output); err != nil {
+		return fmt.Errorf("failed to write batch output %q: %w", output.CustomID, err)
+	}
+	return nil
+}
+
+// ReadOutputs calls fn for every line of a batch output or error file.
+func ReadOutputs(r io.Reader, fn func(Output) error) error {
+	decoder := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var output Output
+		err := decoder.Decode(&output)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read batch output line %d: %w", line, err)
+		}
+		if err := fn(output); err != nil {
+			return err
+		}
+	}
+}
+
+// ReadResults maps the lines of output and error files to their custom ids.
+func ReadResults(readers ...io.Reader) (map[string]Result, error) {
+	results := make(map[string]Result)
+	for _, r := range readers {
+		err := ReadOutputs(r, func(output Output) error {
+			results[output.CustomID] = output.Result()
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
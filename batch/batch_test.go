@@ -0,0 +1,95 @@
+package batch_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/batch"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func testRequest(text string) *request.Request {
+	req := request.NewRequest(models.ModelMeta{ModelId: "gpt-4o-mini"})
+	req.SetMessages(request.Messages{request.UserMessageSimple(text)})
+	return req
+}
+
+func TestInputs(t *testing.T) {
+	inputs := batch.NewInputs([]*request.Request{testRequest("one"), testRequest("two")})
+
+	var buf bytes.Buffer
+	require.NoError(t, batch.WriteInputs(&buf, inputs))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"custom_id":"request-0","method":"POST","url":"/v1/chat/completions","body":{"model":"gpt-4o-mini"`)
+
+	read := make([]batch.Input, 0)
+	err := batch.ReadInputs(&buf, func(input batch.Input) error {
+		read = append(read, input)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, read, 2)
+	assert.Equal(t, "request-1", read[1].CustomID)
+	assert.Equal(t, "two", read[1].Body.Messages[0].Contents.String())
+
+	duplicate := []batch.Input{batch.NewInput("a", testRequest("x")), batch.NewInput("a", testRequest("y"))}
+	assert.ErrorContains(t, batch.WriteInputs(&bytes.Buffer{}, duplicate), `duplicate custom_id "a"`)
+	assert.ErrorContains(t, batch.WriteInputs(&bytes.Buffer{}, []batch.Input{{CustomID: "b"}}), `input "b" has no body`)
+
+	err = batch.ReadInputs(strings.NewReader(`{"custom_id":"x"}`), func(batch.Input) error { return nil })
+	assert.ErrorContains(t, err, "batch input line 1 needs custom_id and body")
+}
+
+func TestReadResults(t *testing.T) {
+	output := `{"id":"batch_req_1","custom_id":"request-0","response":{"status_code":200,"request_id":"r1","body":{"id":"chatcmpl-1","choices":[{"message":{"role":"assistant","content":"spam"}}]}},"error":null}
+{"id":"batch_req_2","custom_id":"request-1","response":{"status_code":400,"body":{"error":{"message":"bad model","code":"model_not_found"}}},"error":null}
+`
+	errorFile := `{"id":"batch_req_3","custom_id":"request-2","response":null,"error":{"code":"batch_expired","message":"not processed in time"}}`
+
+	results, err := batch.ReadResults(strings.NewReader(output), strings.NewReader(errorFile))
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	first := results["request-0"]
+	assert.NoError(t, first.Err)
+	assert.Equal(t, "spam", first.Response.String())
+
+	var resultErr *batch.ResultError
+	require.True(t, errors.As(results["request-1"].Err, &resultErr))
+	assert.Equal(t, 400, resultErr.StatusCode)
+	assert.Equal(t, "model_not_found", resultErr.Code)
+	assert.EqualError(t, resultErr, "batch request failed with status 400: bad model")
+
+	assert.EqualError(t, results["request-2"].Err, "batch request failed: not processed in time")
+
+	_, err = batch.ReadResults(strings.NewReader("{not json"))
+	assert.ErrorContains(t, err, "failed to read batch output line 1")
+}
+
+func TestNewOutput(t *testing.T) {
+	res := response.Response{ID: "chatcmpl-9"}
+	res.SetText("ham")
+
+	var buf bytes.Buffer
+	output, err := batch.NewOutput("row-1", res, nil)
+	require.NoError(t, err)
+	require.NoError(t, batch.WriteOutput(&buf, output))
+	output, err = batch.NewOutput("row-2", response.Response{}, errors.New("timeout"))
+	require.NoError(t, err)
+	require.NoError(t, batch.WriteOutput(&buf, output))
+
+	results, err := batch.ReadResults(&buf)
+	require.NoError(t, err)
+	first := results["row-1"]
+	assert.Equal(t, "ham", first.Response.String())
+	assert.Equal(t, "chatcmpl-9", first.Response.ID)
+	assert.EqualError(t, results["row-2"].Err, "batch request failed: timeout")
+}
@@ -0,0 +1,55 @@
+package batch
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter delays requests, e.g. to stay under a provider rate limit.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// RateLimiter spaces requests evenly to at most RequestsPerMinute.
+type RateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func NewRateLimiter(requestsPerMinute int) *RateLimiter {
+	if requestsPerMinute <= 0 {
+		return &RateLimiter{}
+	}
+	return &RateLimiter{interval: time.Minute / time.Duration(requestsPerMinute)}
+}
+
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,316 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// Completer runs one request, *client.Litellm and *cache.Completions implement it.
+type Completer interface {
+	Completion(ctx context.Context, req *request.Request) (response.Response, error)
+}
+
+// Runner executes a batch input file locally, for providers without a batch API.
+// Every finished request is appended to the output file right away, so a crashed or
+// cancelled run resumes where it stopped by skipping the custom ids already in the output.
+type Runner struct {
+	Client Completer
+	// Concurrency limits parallel requests. Defaults to 4.
+	Concurrency int
+	// Limiter is optional, see NewRateLimiter. An input fails when Wait returns an error.
+	// Without it, inputs for a model of Models with an RPM are limited by NewRateLimiter(RPM).
+	Limiter Limiter
+	// RetryFailed runs inputs again whose output line is an error. Their new line is appended,
+	// ReadResults keeps the last line per custom id.
+	RetryFailed bool
+	// Models are used for the cost in Summary, requests for other models cost 0.
+	Models []models.ModelMeta
+	// Progress is called after every finished request. Without it progress is logged every 10 seconds.
+	Progress func(Summary)
+}
+
+// Summary counts the requests of a run.
+type Summary struct {
+	Total int
+	// Skipped inputs were already in the output.
+	Skipped          int
+	Succeeded        int
+	Failed           int
+	PromptTokens     int
+	CompletionTokens int
+	// Cost in USD of the succeeded requests, from the Runner Models prices.
+	Cost    float64
+	Elapsed time.Duration
+}
+
+// Remaining returns the inputs that are not finished yet.
+func (s Summary) Remaining() int {
+	return s.Total - s.Skipped - s.Succeeded - s.Failed
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d/%d done (%d skipped, %d failed), %d prompt + %d completion tokens, $%.4f, %s",
+		s.Skipped+s.Succeeded+s.Failed, s.Total, s.Skipped, s.Failed,
+		s.PromptTokens, s.CompletionTokens, s.Cost, s.Elapsed.Round(time.Second))
+}
+
+// RunFile runs the inputs of inputPath and appends the outputs to outputPath.
+// The input file is read twice, once to count and once streamed while running.
+func (r *Runner) RunFile(ctx context.Context, inputPath, outputPath string) (Summary, error) {
+	completed, err := readCompleted(outputPath, r.RetryFailed)
+	if err != nil {
+		return Summary{}, err
+	}
+
+	total := 0
+	err = readInputFile(inputPath, func(Input) error {
+		total++
+		return nil
+	})
+	if err != nil {
+		return Summary{}, err
+	}
+
+	output, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to open batch output: %w", err)
+	}
+	defer func() {
+		if err := output.Close(); err != nil {
+			log.Printf("Warning: failed to close batch output: %v", err)
+		}
+	}()
+
+	input, err := os.Open(inputPath)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to open batch input: %w", err)
+	}
+	defer input.Close()
+
+	summary, err := r.Run(ctx, input, output, total, completed)
+	log.Printf("batch %s: %s", inputPath, summary)
+	return summary, err
+}
+
+// Run executes inputs and writes one output line per finished request to w.
+// total is only used for progress, completed holds the custom ids to skip.
+// A cancelled ctx stops the run after the running requests finished, a failed write cancels the rest.
+func (r *Runner) Run(ctx context.Context, inputs io.Reader, w io.Writer, total int, completed map[string]bool) (Summary, error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := r.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	prices := make(map[models.ModelID]models.ModelMeta, len(r.Models))
+	limiters := make(map[models.ModelID]Limiter, len(r.Models))
+	for _, model := range r.Models {
+		prices[model.ModelId] = model
+		if r.Limiter == nil && model.RPM > 0 {
+			limiters[model.ModelId] = NewRateLimiter(model.RPM)
+		}
+	}
+
+	start := time.Now()
+	summary := Summary{Total: total}
+	lastLog := start
+	var mu sync.Mutex
+	var writeErr error
+
+	finish := func(input Input, res response.Response, err error) {
+		output, encodeErr := NewOutput(input.CustomID, res, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if encodeErr == nil && writeErr == nil {
+			encodeErr = WriteOutput(w, output)
+		}
+		if encodeErr != nil {
+			if writeErr == nil {
+				writeErr = encodeErr
+				cancel()
+			}
+			return
+		}
+
+		if err != nil {
+			summary.Failed++
+		} else {
+			summary.Succeeded++
+			summary.PromptTokens += res.Usage.PromptTokens
+			summary.CompletionTokens += res.Usage.CompletionTokens
+			price := prices[input.Body.Model]
+			summary.Cost += float64(res.Usage.PromptTokens)*price.InputCostPerToken +
+				float64(res.Usage.CompletionTokens)*price.OutputCostPerToken
+		}
+		summary.Elapsed = time.Since(start)
+
+		if r.Progress != nil {
+			r.Progress(summary)
+		} else if time.Since(lastLog) >= 10*time.Second {
+			lastLog = time.Now()
+			log.Printf("batch progress: %s", summary)
+		}
+	}
+
+	queue := make(chan Input)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for input := range queue {
+				if !isChatEndpoint(input.URL) {
+					finish(input, response.Response{}, fmt.Errorf("unsupported endpoint %q, only chat completions run locally", input.URL))
+					continue
+				}
+				limiter := r.Limiter
+				if limiter == nil {
+					limiter = limiters[input.Body.Model]
+				}
+				if limiter != nil {
+					if err := limiter.Wait(runCtx); err != nil {
+						if runCtx.Err() == nil {
+							finish(input, response.Response{}, fmt.Errorf("rate limiter failed: %w", err))
+						}
+						continue
+					}
+				}
+				res, err := r.Client.Completion(runCtx, input.Body)
+				if err != nil && runCtx.Err() != nil {
+					// cancelled requests stay unfinished and run again on resume
+					continue
+				}
+				finish(input, res, err)
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+	readErr := ReadInputs(inputs, func(input Input) error {
+		if seen[input.CustomID] {
+			return fmt.Errorf("duplicate custom_id %q", input.CustomID)
+		}
+		seen[input.CustomID] = true
+		if completed[input.CustomID] {
+			mu.Lock()
+			summary.Skipped++
+			mu.Unlock()
+			return nil
+		}
+		select {
+		case queue <- input:
+			return nil
+		case <-runCtx.Done():
+			return runCtx.Err()
+		}
+	})
+	close(queue)
+	wg.Wait()
+
+	summary.Elapsed = time.Since(start)
+	if writeErr != nil {
+		return summary, writeErr
+	}
+	if readErr != nil {
+		return summary, readErr
+	}
+	return summary, ctx.Err()
+}
+
+func isChatEndpoint(url string) bool {
+	return url == "" || strings.TrimPrefix(url, "/v1") == "/chat/completions"
+}
+
+func readInputFile(path string, fn func(Input) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open batch input: %w", err)
+	}
+	defer file.Close()
+	return ReadInputs(file, fn)
+}
+
+// readCompleted returns the custom ids in the output file. A last line cut off by a
+// crash is removed, so new lines are appended to valid JSONL. A complete last line
+// without a trailing newline is kept and the newline is added.
+func readCompleted(path string, retryFailed bool) (map[string]bool, error) {
+	completed := make(map[string]bool)
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return completed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open batch output: %w", err)
+	}
+	defer file.Close()
+
+	record := func(line []byte) error {
+		if len(bytes.TrimSpace(line)) == 0 {
+			return nil
+		}
+		var output Output
+		if err := json.Unmarshal(line, &output); err != nil {
+			return err
+		}
+		if retryFailed && output.Result().Err != nil {
+			delete(completed, output.CustomID)
+			return nil
+		}
+		completed[output.CustomID] = true
+		return nil
+	}
+
+	reader := bufio.NewReader(file)
+	valid := int64(0)
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) == 0 {
+				return completed, nil
+			}
+			if record(line) == nil {
+				return completed, appendNewline(path)
+			}
+			if err := os.Truncate(path, valid); err != nil {
+				return nil, fmt.Errorf("failed to remove incomplete batch output line: %w", err)
+			}
+			return completed, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read batch output: %w", err)
+		}
+		valid += int64(len(line))
+		if err := record(line); err != nil {
+			return nil, fmt.Errorf("failed to read batch output line %d: %w", lineNumber, err)
+		}
+	}
+}
+
+func appendNewline(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open batch output: %w", err)
+	}
+	if _, err := file.Write([]byte("\n")); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to complete last batch output line: %w", err)
+	}
+	return file.Close()
+}
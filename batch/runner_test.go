@@ -0,0 +1,274 @@
+package batch_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/batch"
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+var _ batch.Completer = (*client.Litellm)(nil)
+
+// fakeCompleter answers with the message text and fails texts starting with "fail".
+type fakeCompleter struct {
+	mu    sync.Mutex
+	calls []string
+	// cancel is called when the message equals cancelAt.
+	cancel   context.CancelFunc
+	cancelAt string
+}
+
+func (f *fakeCompleter) Completion(ctx context.Context, req *request.Request) (response.Response, error) {
+	text := req.Messages[0].Contents.String()
+	f.mu.Lock()
+	f.calls = append(f.calls, text)
+	f.mu.Unlock()
+
+	if text == f.cancelAt && f.cancel != nil {
+		f.cancel()
+		return response.Response{}, ctx.Err()
+	}
+	if strings.HasPrefix(text, "fail") {
+		return response.Response{}, errors.New("model overloaded")
+	}
+	res := response.Response{ID: "resp-" + text, Usage: response.ResponseUsage{PromptTokens: 10, CompletionTokens: 2}}
+	res.SetText("label " + text)
+	return res, nil
+}
+
+func writeInputFile(t *testing.T, texts ...string) string {
+	requests := make([]*request.Request, len(texts))
+	for i, text := range texts {
+		requests[i] = testRequest(text)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, batch.WriteInputs(&buf, batch.NewInputs(requests)))
+	path := filepath.Join(t.TempDir(), "input.jsonl")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+	return path
+}
+
+func readResultFile(t *testing.T, path string) map[string]batch.Result {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	results, err := batch.ReadResults(file)
+	require.NoError(t, err)
+	return results
+}
+
+func TestRunner_RunFile(t *testing.T) {
+	input := writeInputFile(t, "a", "b", "fail-c", "d")
+	output := filepath.Join(t.TempDir(), "output.jsonl")
+	fake := &fakeCompleter{}
+	progress := 0
+	runner := batch.Runner{
+		Client:      fake,
+		Concurrency: 2,
+		Models:      []models.ModelMeta{{ModelId: "gpt-4o-mini", InputCostPerToken: 0.001, OutputCostPerToken: 0.01}},
+		Progress:    func(batch.Summary) { progress++ },
+	}
+
+	summary, err := runner.RunFile(context.Background(), input, output)
+	require.NoError(t, err)
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 3, summary.Succeeded)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, 0, summary.Remaining())
+	assert.Equal(t, 30, summary.PromptTokens)
+	assert.Equal(t, 6, summary.CompletionTokens)
+	assert.InDelta(t, 0.09, summary.Cost, 1e-9)
+	assert.Equal(t, 4, progress)
+	assert.Contains(t, summary.String(), "4/4 done (0 skipped, 1 failed), 30 prompt + 6 completion tokens, $0.0900")
+
+	results := readResultFile(t, output)
+	require.Len(t, results, 4)
+	second := results["request-1"]
+	assert.Equal(t, "label b", second.Response.String())
+	assert.EqualError(t, results["request-2"].Err, "batch request failed: model overloaded")
+
+	// a second run skips everything, with RetryFailed only the failed input runs again
+	summary, err = runner.RunFile(context.Background(), input, output)
+	require.NoError(t, err)
+	assert.Equal(t, 4, summary.Skipped)
+	assert.Len(t, fake.calls, 4)
+
+	runner.RetryFailed = true
+	summary, err = runner.RunFile(context.Background(), input, output)
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Skipped)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, "fail-c", fake.calls[4])
+}
+
+func TestRunner_Resume(t *testing.T) {
+	input := writeInputFile(t, "a", "b", "c", "d", "e")
+	output := filepath.Join(t.TempDir(), "output.jsonl")
+
+	// a crash left two finished lines and half of a third one
+	var previous bytes.Buffer
+	for _, id := range []string{"request-0", "request-3"} {
+		res := response.Response{}
+		res.SetText("earlier")
+		line, err := batch.NewOutput(id, res, nil)
+		require.NoError(t, err)
+		require.NoError(t, batch.WriteOutput(&previous, line))
+	}
+	previous.WriteString(`{"custom_id":"request-1","respon`)
+	require.NoError(t, os.WriteFile(output, previous.Bytes(), 0o644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fake := &fakeCompleter{cancel: cancel, cancelAt: "c"}
+	runner := batch.Runner{Client: fake, Concurrency: 1}
+
+	summary, err := runner.RunFile(ctx, input, output)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, summary.Skipped)
+	assert.Equal(t, 1, summary.Succeeded)
+	assert.Equal(t, 2, summary.Remaining())
+
+	fake.cancelAt = ""
+	summary, err = runner.RunFile(context.Background(), input, output)
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Skipped)
+	assert.Equal(t, 2, summary.Succeeded)
+	assert.Equal(t, []string{"b", "c", "c", "e"}, fake.calls)
+
+	results := readResultFile(t, output)
+	require.Len(t, results, 5)
+	first, third := results["request-0"], results["request-2"]
+	assert.Equal(t, "earlier", first.Response.String())
+	assert.Equal(t, "label c", third.Response.String())
+}
+
+func TestRunner_ResumeKeepsLastLineWithoutNewline(t *testing.T) {
+	input := writeInputFile(t, "a", "b")
+	output := filepath.Join(t.TempDir(), "output.jsonl")
+
+	res := response.Response{}
+	res.SetText("earlier")
+	line, err := batch.NewOutput("request-0", res, nil)
+	require.NoError(t, err)
+	var previous bytes.Buffer
+	require.NoError(t, batch.WriteOutput(&previous, line))
+	require.NoError(t, os.WriteFile(output, bytes.TrimSuffix(previous.Bytes(), []byte("\n")), 0o644))
+
+	fake := &fakeCompleter{}
+	summary, err := (&batch.Runner{Client: fake}).RunFile(context.Background(), input, output)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Skipped)
+	assert.Equal(t, []string{"b"}, fake.calls)
+
+	results := readResultFile(t, output)
+	require.Len(t, results, 2)
+	first, second := results["request-0"], results["request-1"]
+	assert.Equal(t, "earlier", first.Response.String())
+	assert.Equal(t, "label b", second.Response.String())
+}
+
+func TestRunner_UnsupportedEndpoint(t *testing.T) {
+	inputs := []batch.Input{{CustomID: "e1", Method: "POST", URL: "/v1/embeddings", Body: testRequest("x")}}
+	var in, out bytes.Buffer
+	require.NoError(t, batch.WriteInputs(&in, inputs))
+
+	fake := &fakeCompleter{}
+	summary, err := (&batch.Runner{Client: fake}).Run(context.Background(), &in, &out, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Empty(t, fake.calls)
+
+	results, err := batch.ReadResults(&out)
+	require.NoError(t, err)
+	assert.ErrorContains(t, results["e1"].Err, `unsupported endpoint "/v1/embeddings"`)
+}
+
+func TestRateLimiter(t *testing.T) {
+	limiter := batch.NewRateLimiter(60 * 200) // one request every 5ms
+	start := time.Now()
+	for range 5 {
+		require.NoError(t, limiter.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, batch.NewRateLimiter(1).Wait(ctx), context.Canceled)
+	assert.NoError(t, batch.NewRateLimiter(0).Wait(context.Background()))
+}
+
+// failingWriter fails every write after the first n.
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n <= 0 {
+		return 0, errors.New("disk full")
+	}
+	w.n--
+	return len(p), nil
+}
+
+func TestRunner_WriteErrorStopsRun(t *testing.T) {
+	requests := make([]*request.Request, 50)
+	for i := range requests {
+		requests[i] = testRequest("row")
+	}
+	var in bytes.Buffer
+	require.NoError(t, batch.WriteInputs(&in, batch.NewInputs(requests)))
+
+	fake := &fakeCompleter{}
+	summary, err := (&batch.Runner{Client: fake, Concurrency: 1}).Run(context.Background(), &in, &failingWriter{n: 1}, len(requests), nil)
+	assert.ErrorContains(t, err, "disk full")
+	assert.Equal(t, 1, summary.Succeeded)
+	assert.Less(t, len(fake.calls), len(requests))
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Wait(context.Context) error {
+	return errors.New("quota exhausted")
+}
+
+func TestRunner_LimiterError(t *testing.T) {
+	var in, out bytes.Buffer
+	require.NoError(t, batch.WriteInputs(&in, batch.NewInputs([]*request.Request{testRequest("a")})))
+
+	fake := &fakeCompleter{}
+	summary, err := (&batch.Runner{Client: fake, Limiter: failingLimiter{}}).Run(context.Background(), &in, &out, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summary.Failed)
+	assert.Empty(t, fake.calls)
+
+	results, err := batch.ReadResults(&out)
+	require.NoError(t, err)
+	assert.ErrorContains(t, results["request-0"].Err, "rate limiter failed: quota exhausted")
+}
+
+func TestRunner_DefaultLimiterFromModelRPM(t *testing.T) {
+	var in, out bytes.Buffer
+	require.NoError(t, batch.WriteInputs(&in, batch.NewInputs([]*request.Request{testRequest("a"), testRequest("b"), testRequest("c")})))
+
+	// 1200 rpm spaces requests 50ms apart
+	model := models.ModelMeta{ModelId: "gpt-4o-mini", RPM: 1200}
+	start := time.Now()
+	summary, err := (&batch.Runner{Client: &fakeCompleter{}, Models: []models.ModelMeta{model}}).Run(context.Background(), &in, &out, 3, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Succeeded)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var boltBucket = []byte("cache")
+
+// Bolt is a Store in a single BoltDB file, for corpora too large for one file per key.
+// A BoltDB file is locked by one process at a time. Expired values are removed when read
+// or by DeleteExpired.
+type Bolt struct {
+	db *bolt.DB
+}
+
+// NewBolt opens or creates the database file at path. Call Close when done.
+func NewBolt(path string) (*Bolt, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cache database %q: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create cache bucket: %w", err)
+	}
+	return &Bolt{db: db}, nil
+}
+
+func (b *Bolt) Close() error {
+	return b.db.Close()
+}
+
+func (b *Bolt) Get(_ context.Context, key string) ([]byte, bool, error) {
+	var value []byte
+	found, stale := false, false
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(boltBucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		if len(data) < 8 || boltExpired(data) {
+			stale = true
+			return nil
+		}
+		// data is only valid inside the transaction
+		value = append([]byte{}, data[8:]...)
+		found = true
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache database: %w", err)
+	}
+	if stale {
+		// check again, the key may have been set since the read
+		_ = b.db.Update(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(boltBucket)
+			if data := bucket.Get([]byte(key)); data != nil && (len(data) < 8 || boltExpired(data)) {
+				return bucket.Delete([]byte(key))
+			}
+			return nil
+		})
+	}
+	return value, found, nil
+}
+
+func (b *Bolt) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	// the first 8 bytes are the expiry in unix nanoseconds, 0 for none, like Disk files
+	data := make([]byte, 8+len(value))
+	if expiresAt := expiry(ttl); !expiresAt.IsZero() {
+		binary.LittleEndian.PutUint64(data[:8], uint64(expiresAt.UnixNano()))
+	}
+	copy(data[8:], value)
+
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put([]byte(key), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write cache database: %w", err)
+	}
+	return nil
+}
+
+func (b *Bolt) Delete(_ context.Context, key string) error {
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete([]byte(key))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete from cache database: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes all expired values and returns how many were removed.
+func (b *Bolt) DeleteExpired(_ context.Context) (int, error) {
+	removed := 0
+	err := b.db.Update(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltBucket).Cursor()
+		for key, data := cursor.First(); key != nil; {
+			if len(data) >= 8 && !boltExpired(data) {
+				key, data = cursor.Next()
+				continue
+			}
+			// key points into the page that Delete modifies
+			key = bytes.Clone(key)
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+			removed++
+			// Next can skip an item after Delete, Seek lands on the one after the deleted key
+			key, data = cursor.Seek(key)
+		}
+		return nil
+	})
+	if err != nil {
+		return removed, fmt.Errorf("failed to delete expired values: %w", err)
+	}
+	return removed, nil
+}
+
+func boltExpired(data []byte) bool {
+	nanos := int64(binary.LittleEndian.Uint64(data[:8]))
+	return nanos != 0 && expired(time.Unix(0, nanos))
+}
@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// CompletionClient is the completion part of *client.Litellm.
+type CompletionClient interface {
+	Completion(ctx context.Context, req *request.Request) (response.Response, error)
+}
+
+// Moderator is implemented by *client.Litellm. When the client is a Moderator, cached
+// responses are only returned after Moderate passed, so the ModerationGuard also covers hits.
+type Moderator interface {
+	Moderate(ctx context.Context, req *request.Request) error
+}
+
+// moderateHit runs the client's moderation before a cached response is returned.
+// Misses are moderated by the client itself.
+func moderateHit(ctx context.Context, client CompletionClient, req *request.Request) error {
+	moderator, ok := client.(Moderator)
+	if !ok {
+		return nil
+	}
+	return moderator.Moderate(ctx, req)
+}
+
+// Completions caches completion responses in front of a CompletionClient, keyed by
+// a canonical hash of the request. It is meant for deterministic calls such as
+// temperature 0 classification, sampled responses would be repeated as well.
+//
+// The LiteLLM cache controls of a request (request.LiteLLMCache) are honored locally:
+// NoCache skips the lookup, NoStore skips storing, TTL overrides the default TTL, SMaxAge
+// rejects older entries and Namespace is part of the key. Streaming requests are never cached.
+type Completions struct {
+	Client CompletionClient
+	Store  Store
+	// TTL of stored responses, 0 keeps them until evicted.
+	TTL time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func NewCompletions(client CompletionClient, store Store, ttl time.Duration) *Completions {
+	return &Completions{Client: client, Store: store, TTL: ttl}
+}
+
+type bypassKey struct{}
+
+// Bypass returns a context that makes Completions call the API without lookup or store,
+// without sending cache controls to LiteLLM.
+func Bypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+func bypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassKey{}).(bool)
+	return bypass
+}
+
+func (c *Completions) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+}
+
+func (c *Completions) ResetStats() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+}
+
+// Completion has the signature of Litellm.Completion. Cached responses have FromCache set.
+func (c *Completions) Completion(ctx context.Context, req *request.Request) (response.Response, error) {
+	if req != nil && bypassed(ctx) {
+		uncached := *req
+		uncached.Cache = nil
+		return c.Client.Completion(ctx, &uncached)
+	}
+	if req == nil || req.Stream {
+		return c.Client.Completion(ctx, req)
+	}
+
+	key, err := CompletionKey(req)
+	if err != nil {
+		return response.Response{}, err
+	}
+	controls := request.LiteLLMCache{}
+	if req.Cache != nil {
+		controls = *req.Cache
+	}
+
+	if !controls.NoCache {
+		value, found, err := c.Store.Get(ctx, key)
+		if err != nil {
+			log.Printf("Warning: completion cache lookup failed: %v", err)
+		}
+		var entry cachedCompletion
+		if found {
+			if err := json.Unmarshal(value, &entry); err != nil {
+				log.Printf("Warning: ignoring unreadable cached completion: %v", err)
+				found = false
+			}
+		}
+		if found && (controls.SMaxAge <= 0 || time.Since(entry.StoredAt) <= time.Duration(controls.SMaxAge)*time.Second) {
+			if err := moderateHit(ctx, c.Client, req); err != nil {
+				return response.Response{}, err
+			}
+			c.hits.Add(1)
+			entry.Response.FromCache = true
+			return entry.Response, nil
+		}
+	}
+	c.misses.Add(1)
+
+	res, err := c.Client.Completion(ctx, req)
+	if err != nil || controls.NoStore || len(res.Choices) == 0 {
+		return res, err
+	}
+
+	ttl := c.TTL
+	if controls.TTL > 0 {
+		ttl = time.Duration(controls.TTL) * time.Second
+	}
+	value, err := json.Marshal(cachedCompletion{StoredAt: time.Now(), Response: res})
+	if err == nil {
+		err = c.Store.Set(ctx, key, value, ttl)
+	}
+	if err != nil {
+		log.Printf("Warning: completion cache store failed: %v", err)
+	}
+	return res, nil
+}
+
+// cachedCompletion is the stored value, StoredAt is checked against SMaxAge.
+type cachedCompletion struct {
+	StoredAt time.Time         `json:"stored_at"`
+	Response response.Response `json:"response"`
+}
+
+// CompletionKey hashes everything that changes a response: model, messages, tools,
+// response_format and sampling params. Metadata, user and the cache controls are
+// left out, except for the cache namespace.
+func CompletionKey(req *request.Request) (string, error) {
+	canonical := *req
+	namespace := ""
+	if canonical.Cache != nil {
+		namespace = canonical.Cache.Namespace
+	}
+	canonical.Cache = nil
+	canonical.Metadata = nil
+	canonical.User = ""
+	canonical.Stream = false
+
+	// encoding/json sorts map keys, so equal requests encode equally
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("completion:%s:%s:%s", namespace, canonical.Model, hex.EncodeToString(sum[:])), nil
+}
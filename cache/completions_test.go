@@ -0,0 +1,207 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/cache"
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+var (
+	_ cache.CompletionClient = (*client.Litellm)(nil)
+	_ cache.Moderator        = (*client.Litellm)(nil)
+)
+
+type fakeCompleter struct {
+	calls int
+	err   error
+	last  *request.Request
+}
+
+func (f *fakeCompleter) Completion(_ context.Context, req *request.Request) (response.Response, error) {
+	f.calls++
+	f.last = req
+	if f.err != nil {
+		return response.Response{}, f.err
+	}
+	res := response.Response{ID: "resp", Model: req.Model}
+	res.SetText("answer " + req.Messages[len(req.Messages)-1].Contents.String())
+	return res, nil
+}
+
+type moderatedCompleter struct {
+	fakeCompleter
+	moderated int
+	err       error
+}
+
+func (f *moderatedCompleter) Moderate(_ context.Context, _ *request.Request) error {
+	f.moderated++
+	return f.err
+}
+
+func classifyRequest(text string) *request.Request {
+	req := request.NewRequest(models.ModelMeta{ModelId: "gpt-4o-mini"})
+	req.SetMessages(request.Messages{request.SystemMessageSimple("Classify."), request.UserMessageSimple(text)})
+	return req
+}
+
+func TestCompletions_Completion(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	completions := cache.NewCompletions(fake, cache.NewMemory(0), 0)
+
+	res, err := completions.Completion(ctx, classifyRequest("spam?"))
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+	assert.Equal(t, "answer spam?", res.String())
+
+	res, err = completions.Completion(ctx, classifyRequest("spam?"))
+	require.NoError(t, err)
+	assert.True(t, res.FromCache)
+	assert.Equal(t, "answer spam?", res.String())
+	assert.Equal(t, "resp", res.ID)
+	assert.Equal(t, 1, fake.calls)
+
+	// metadata and user do not change the key, params do
+	req := classifyRequest("spam?")
+	req.User = "u1"
+	req.Metadata = map[string]any{"trace": "x"}
+	res, err = completions.Completion(ctx, req)
+	require.NoError(t, err)
+	assert.True(t, res.FromCache)
+
+	req = classifyRequest("spam?")
+	req.SetJSONMode()
+	res, err = completions.Completion(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+	assert.Equal(t, cache.Stats{Hits: 2, Misses: 2}, completions.Stats())
+	assert.Equal(t, 2, fake.calls)
+
+	// per call bypass, cache controls are not sent
+	bypassed := classifyRequest("spam?").SetCache(request.LiteLLMCache{NoStore: true})
+	res, err = completions.Completion(cache.Bypass(ctx), bypassed)
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+	assert.Equal(t, 3, fake.calls)
+	assert.Nil(t, fake.last.Cache)
+	assert.NotNil(t, bypassed.Cache)
+
+	fake.err = errors.New("overloaded")
+	_, err = completions.Completion(ctx, classifyRequest("new"))
+	assert.ErrorContains(t, err, "overloaded")
+	fake.err = nil
+	_, err = completions.Completion(ctx, classifyRequest("new"))
+	require.NoError(t, err)
+	assert.Equal(t, 5, fake.calls)
+}
+
+func TestCompletions_LiteLLMCacheControls(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	completions := cache.NewCompletions(fake, cache.NewMemory(0), 0)
+
+	// no-store does not fill the cache
+	_, err := completions.Completion(ctx, classifyRequest("a").SetCache(request.LiteLLMCache{NoStore: true}))
+	require.NoError(t, err)
+	res, err := completions.Completion(ctx, classifyRequest("a"))
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+
+	// no-cache skips the lookup but refreshes the entry
+	res, err = completions.Completion(ctx, classifyRequest("a").SetCache(request.LiteLLMCache{NoCache: true}))
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+	res, err = completions.Completion(ctx, classifyRequest("a"))
+	require.NoError(t, err)
+	assert.True(t, res.FromCache)
+
+	// namespaces are separate
+	res, err = completions.Completion(ctx, classifyRequest("a").SetCache(request.LiteLLMCache{Namespace: "tenant-1"}))
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+	res, err = completions.Completion(ctx, classifyRequest("a").SetCache(request.LiteLLMCache{Namespace: "tenant-1", TTL: 60}))
+	require.NoError(t, err)
+	assert.True(t, res.FromCache)
+
+	// streaming requests are passed through
+	req := classifyRequest("a")
+	req.Stream = true
+	res, err = completions.Completion(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+	assert.Equal(t, 5, fake.calls)
+}
+
+func TestCompletions_SMaxAge(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	store := cache.NewMemory(0)
+	completions := cache.NewCompletions(fake, store, 0)
+
+	key, err := cache.CompletionKey(classifyRequest("a"))
+	require.NoError(t, err)
+	stale := `{"stored_at":"` + time.Now().Add(-time.Hour).Format(time.RFC3339) + `","response":{"id":"old","choices":[{"message":{"role":"assistant","content":"old"}}]}}`
+	require.NoError(t, store.Set(ctx, key, []byte(stale), 0))
+
+	res, err := completions.Completion(ctx, classifyRequest("a"))
+	require.NoError(t, err)
+	assert.True(t, res.FromCache)
+	assert.Equal(t, "old", res.ID)
+
+	res, err = completions.Completion(ctx, classifyRequest("a").SetCache(request.LiteLLMCache{SMaxAge: 60}))
+	require.NoError(t, err)
+	assert.False(t, res.FromCache)
+	assert.Equal(t, 1, fake.calls)
+
+	// the refreshed entry is young enough
+	res, err = completions.Completion(ctx, classifyRequest("a").SetCache(request.LiteLLMCache{SMaxAge: 60}))
+	require.NoError(t, err)
+	assert.True(t, res.FromCache)
+	assert.Equal(t, "resp", res.ID)
+}
+
+func TestCompletions_ModeratesHits(t *testing.T) {
+	ctx := context.Background()
+	fake := &moderatedCompleter{}
+	completions := cache.NewCompletions(fake, cache.NewMemory(0), 0)
+
+	_, err := completions.Completion(ctx, classifyRequest("a"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, fake.moderated, "misses are moderated by the client")
+
+	res, err := completions.Completion(ctx, classifyRequest("a"))
+	require.NoError(t, err)
+	assert.True(t, res.FromCache)
+	assert.Equal(t, 1, fake.moderated)
+
+	fake.err = errors.New("blocked by moderation: hate (0.90)")
+	_, err = completions.Completion(ctx, classifyRequest("a"))
+	require.EqualError(t, err, "blocked by moderation: hate (0.90)")
+	assert.Equal(t, 1, fake.calls)
+}
+
+func TestCompletionKey(t *testing.T) {
+	a, err := cache.CompletionKey(classifyRequest("x").SetCache(request.LiteLLMCache{NoCache: true, TTL: 10}))
+	require.NoError(t, err)
+	b, err := cache.CompletionKey(classifyRequest("x"))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.Contains(t, a, "completion::gpt-4o-mini:")
+
+	temperature := classifyRequest("x")
+	temperature.Temperature = 0.5
+	c, err := cache.CompletionKey(temperature)
+	require.NoError(t, err)
+	assert.NotEqual(t, b, c)
+}
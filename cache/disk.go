@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Disk is a Store with one file per key below a directory. Values survive restarts
+// and can be shared by processes on one machine. Expired files are removed when read.
+type Disk struct {
+	dir string
+}
+
+// NewDisk creates dir when missing.
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %q: %w", dir, err)
+	}
+	return &Disk{dir: dir}, nil
+}
+
+// path shards files by the first hash byte, keys are hashed because they may contain any characters.
+func (d *Disk) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(d.dir, name[:2], name)
+}
+
+func (d *Disk) Get(_ context.Context, key string) ([]byte, bool, error) {
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache file: %w", err)
+	}
+	if len(data) < 8 {
+		_ = os.Remove(path)
+		return nil, false, nil
+	}
+
+	// the first 8 bytes are the expiry in unix nanoseconds, 0 for none
+	var expiresAt time.Time
+	if nanos := int64(binary.LittleEndian.Uint64(data[:8])); nanos != 0 {
+		expiresAt = time.Unix(0, nanos)
+	}
+	if expired(expiresAt) {
+		_ = os.Remove(path)
+		return nil, false, nil
+	}
+	return data[8:], true, nil
+}
+
+func (d *Disk) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	path := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data := make([]byte, 8+len(value))
+	if expiresAt := expiry(ttl); !expiresAt.IsZero() {
+		binary.LittleEndian.PutUint64(data[:8], uint64(expiresAt.UnixNano()))
+	}
+	copy(data[8:], value)
+
+	// write and rename, so readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache file: %w", err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
+
+func (d *Disk) Delete(_ context.Context, key string) error {
+	err := os.Remove(d.path(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete cache file: %w", err)
+	}
+	return nil
+}
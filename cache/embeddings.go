@@ -0,0 +1,261 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// EmbeddingClient is the embeddings part of *client.Litellm.
+type EmbeddingClient interface {
+	EmbeddingsRequest(ctx context.Context, req request.EmbeddingRequest) (response.EmbeddingResponse, error)
+	EmbeddingsBatch(ctx context.Context, model models.ModelMeta, inputs []string, options request.EmbeddingBatchOptions) (response.EmbeddingBatchResponse, error)
+}
+
+// Embeddings caches embeddings in front of an EmbeddingClient, keyed by model, dimensions,
+// input type and the sha256 of the input. Only inputs missing in Store are sent, so
+// re-embedding a mostly unchanged corpus costs only the changed documents.
+// Usage in responses counts the sent inputs only. Store failures are logged and treated as misses.
+type Embeddings struct {
+	Client EmbeddingClient
+	Store  Store
+	// TTL of stored embeddings, 0 keeps them until evicted.
+	TTL time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+func NewEmbeddings(client EmbeddingClient, store Store) *Embeddings {
+	return &Embeddings{Client: client, Store: store}
+}
+
+// Stats returns the lookups since creation or the last ResetStats.
+func (e *Embeddings) Stats() Stats {
+	return Stats{Hits: e.hits.Load(), Misses: e.misses.Load()}
+}
+
+func (e *Embeddings) ResetStats() {
+	e.hits.Store(0)
+	e.misses.Store(0)
+}
+
+// Embeddings has the signature of Litellm.Embeddings.
+func (e *Embeddings) Embeddings(ctx context.Context, model models.ModelMeta, inputText string) (response.EmbeddingResponse, error) {
+	if inputText == "" {
+		return response.EmbeddingResponse{}, fmt.Errorf("inputText cannot be empty")
+	}
+	return e.EmbeddingsRequest(ctx, request.EmbeddingRequest{Model: string(model.ModelId), Input: inputText})
+}
+
+// EmbeddingsRequest returns cached embeddings and sends the missing inputs in one request.
+// Duplicate inputs are sent once.
+func (e *Embeddings) EmbeddingsRequest(ctx context.Context, req request.EmbeddingRequest) (response.EmbeddingResponse, error) {
+	inputs := req.Inputs
+	if len(inputs) == 0 {
+		if req.Input == "" {
+			return response.EmbeddingResponse{}, fmt.Errorf("input cannot be empty")
+		}
+		inputs = []string{req.Input}
+	}
+
+	res := response.EmbeddingResponse{Object: "list", Model: req.Model, Data: make([]response.EmbeddingData, len(inputs))}
+	keys := make([]string, len(inputs))
+	missing := e.lookup(ctx, req.Model, req.EmbeddingParams, inputs, keys, res.Data)
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	texts := make([]string, len(missing))
+	for i, positions := range missing {
+		texts[i] = inputs[positions[0]]
+	}
+	missReq := req
+	missReq.Input, missReq.Inputs = "", nil
+	if len(texts) == 1 {
+		missReq.Input = texts[0]
+	} else {
+		missReq.Inputs = texts
+	}
+
+	sent, err := e.Client.EmbeddingsRequest(ctx, missReq)
+	if err != nil {
+		return response.EmbeddingResponse{}, err
+	}
+
+	received := make([]bool, len(texts))
+	for _, data := range sent.Data {
+		if data.Index < 0 || data.Index >= len(texts) {
+			return response.EmbeddingResponse{}, fmt.Errorf("embedding index %d out of range", data.Index)
+		}
+		received[data.Index] = true
+		e.store(ctx, keys[missing[data.Index][0]], data.Embedding)
+		for _, pos := range missing[data.Index] {
+			res.Data[pos].Embedding = data.Embedding
+		}
+	}
+	for i, ok := range received {
+		if !ok {
+			return response.EmbeddingResponse{}, fmt.Errorf("embedding missing in response for input %d", missing[i][0])
+		}
+	}
+
+	if sent.Model != "" {
+		res.Model = sent.Model
+	}
+	res.Usage = sent.Usage
+	return res, nil
+}
+
+// EmbeddingsBatch returns cached embeddings and embeds the missing inputs with Client.EmbeddingsBatch.
+func (e *Embeddings) EmbeddingsBatch(ctx context.Context, model models.ModelMeta, inputs []string, options request.EmbeddingBatchOptions) (response.EmbeddingBatchResponse, error) {
+	if len(inputs) == 0 {
+		return response.EmbeddingBatchResponse{}, fmt.Errorf("inputs cannot be empty")
+	}
+	err := options.Params.Validate()
+	if err != nil {
+		return response.EmbeddingBatchResponse{}, fmt.Errorf("invalid request: %w", err)
+	}
+
+	res := response.EmbeddingBatchResponse{
+		Model:  string(model.ModelId),
+		Data:   make([]response.EmbeddingData, len(inputs)),
+		Errors: make([]error, len(inputs)),
+	}
+
+	// empty inputs are reported like Litellm.EmbeddingsBatch does, without a lookup
+	indexes := make([]int, 0, len(inputs))
+	texts := make([]string, 0, len(inputs))
+	for i, input := range inputs {
+		res.Data[i] = response.EmbeddingData{Object: "embedding", Index: i}
+		if input == "" {
+			res.Errors[i] = fmt.Errorf("input %d cannot be empty", i)
+			continue
+		}
+		indexes = append(indexes, i)
+		texts = append(texts, input)
+	}
+
+	keys := make([]string, len(texts))
+	data := make([]response.EmbeddingData, len(texts))
+	missing := e.lookup(ctx, res.Model, options.Params, texts, keys, data)
+	for i, d := range data {
+		res.Data[indexes[i]].Embedding = d.Embedding
+	}
+	if len(missing) == 0 {
+		return res, nil
+	}
+
+	missTexts := make([]string, len(missing))
+	for i, positions := range missing {
+		missTexts[i] = texts[positions[0]]
+	}
+	sent, err := e.Client.EmbeddingsBatch(ctx, model, missTexts, options)
+	if err != nil {
+		return response.EmbeddingBatchResponse{}, err
+	}
+
+	for i, positions := range missing {
+		var embedding response.Embedding
+		var embedErr error
+		if i < len(sent.Errors) {
+			embedErr = sent.Errors[i]
+		}
+		if i < len(sent.Data) {
+			embedding = sent.Data[i].Embedding
+		}
+		if embedErr == nil && len(embedding) == 0 {
+			embedErr = fmt.Errorf("embedding missing in response")
+		}
+		if embedErr == nil {
+			e.store(ctx, keys[positions[0]], embedding)
+		}
+		for _, pos := range positions {
+			res.Data[indexes[pos]].Embedding = embedding
+			res.Errors[indexes[pos]] = embedErr
+		}
+	}
+	if sent.Model != "" {
+		res.Model = sent.Model
+	}
+	res.Usage = sent.Usage
+	return res, nil
+}
+
+// lookup fills keys and the embeddings found in the store into data. It returns the
+// positions of the missing inputs, grouped by key so duplicates are embedded once.
+func (e *Embeddings) lookup(ctx context.Context, model string, params request.EmbeddingParams, inputs []string, keys []string, data []response.EmbeddingData) [][]int {
+	missing := make([][]int, 0)
+	missingByKey := make(map[string]int)
+	for i, input := range inputs {
+		keys[i] = EmbeddingKey(model, params, input)
+		data[i] = response.EmbeddingData{Object: "embedding", Index: i}
+
+		if m, ok := missingByKey[keys[i]]; ok {
+			e.misses.Add(1)
+			missing[m] = append(missing[m], i)
+			continue
+		}
+
+		value, found, err := e.Store.Get(ctx, keys[i])
+		if err != nil {
+			log.Printf("Warning: embedding cache lookup failed: %v", err)
+		}
+		if embedding, ok := decodeEmbedding(value); found && ok {
+			e.hits.Add(1)
+			data[i].Embedding = embedding
+			continue
+		}
+
+		e.misses.Add(1)
+		missingByKey[keys[i]] = len(missing)
+		missing = append(missing, []int{i})
+	}
+	return missing
+}
+
+func (e *Embeddings) store(ctx context.Context, key string, embedding response.Embedding) {
+	if len(embedding) == 0 {
+		return
+	}
+	if err := e.Store.Set(ctx, key, encodeEmbedding(embedding), e.TTL); err != nil {
+		log.Printf("Warning: embedding cache store failed: %v", err)
+	}
+}
+
+// EmbeddingKey identifies an embedding by everything that changes the vector.
+// The encoding format is left out, both formats decode to the same values.
+func EmbeddingKey(model string, params request.EmbeddingParams, input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return fmt.Sprintf("embedding:%s:%d:%s:%s", model, params.Dimensions, params.InputType, hex.EncodeToString(sum[:]))
+}
+
+// encodeEmbedding stores float64 values, so cached embeddings equal the ones first returned.
+func encodeEmbedding(embedding response.Embedding) []byte {
+	buf := make([]byte, 8*len(embedding))
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint64(buf[8*i:], math.Float64bits(v))
+	}
+	return buf
+}
+
+func decodeEmbedding(buf []byte) (response.Embedding, bool) {
+	if len(buf) == 0 || len(buf)%8 != 0 {
+		return nil, false
+	}
+	embedding := make(response.Embedding, len(buf)/8)
+	for i := range embedding {
+		embedding[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8*i:]))
+	}
+	return embedding, true
+}
@@ -0,0 +1,170 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/cache"
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+	"github.com/andrejsstepanovs/go-litellm/vectorindex"
+)
+
+var (
+	_ cache.EmbeddingClient = (*client.Litellm)(nil)
+	_ vectorindex.Embedder  = (*cache.Embeddings)(nil)
+)
+
+// fakeClient embeds a text as {len(text), dimensions}.
+type fakeClient struct {
+	requests [][]string
+	err      error
+	failText string
+}
+
+func vectorFor(text string, dims int) response.Embedding {
+	return response.Embedding{float64(len(text)), float64(dims)}
+}
+
+func (f *fakeClient) EmbeddingsRequest(_ context.Context, req request.EmbeddingRequest) (response.EmbeddingResponse, error) {
+	inputs := req.Inputs
+	if len(inputs) == 0 {
+		inputs = []string{req.Input}
+	}
+	f.requests = append(f.requests, inputs)
+	if f.err != nil {
+		return response.EmbeddingResponse{}, f.err
+	}
+	res := response.EmbeddingResponse{Model: req.Model, Usage: response.EmbeddingUsage{TotalTokens: len(inputs)}}
+	for i, input := range inputs {
+		res.Data = append(res.Data, response.EmbeddingData{Index: i, Embedding: vectorFor(input, req.Dimensions)})
+	}
+	return res, nil
+}
+
+func (f *fakeClient) EmbeddingsBatch(_ context.Context, model models.ModelMeta, inputs []string, options request.EmbeddingBatchOptions) (response.EmbeddingBatchResponse, error) {
+	f.requests = append(f.requests, inputs)
+	if f.err != nil {
+		return response.EmbeddingBatchResponse{}, f.err
+	}
+	res := response.EmbeddingBatchResponse{
+		Model:  string(model.ModelId),
+		Data:   make([]response.EmbeddingData, len(inputs)),
+		Errors: make([]error, len(inputs)),
+		Usage:  response.EmbeddingUsage{TotalTokens: len(inputs)},
+	}
+	for i, input := range inputs {
+		res.Data[i] = response.EmbeddingData{Index: i}
+		if input == f.failText {
+			res.Errors[i] = fmt.Errorf("input %d failed", i)
+			continue
+		}
+		res.Data[i].Embedding = vectorFor(input, options.Params.Dimensions)
+	}
+	return res, nil
+}
+
+func TestEmbeddings_EmbeddingsRequest(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeClient{}
+	embeddings := cache.NewEmbeddings(fake, cache.NewMemory(0))
+
+	res, err := embeddings.Embeddings(ctx, models.ModelMeta{ModelId: "small"}, "hello")
+	require.NoError(t, err)
+	require.Len(t, res.Data, 1)
+	assert.Equal(t, vectorFor("hello", 0), res.Data[0].Embedding)
+	assert.Equal(t, 1, res.Usage.TotalTokens)
+
+	// only the misses are sent, duplicates once
+	res, err = embeddings.EmbeddingsRequest(ctx, request.EmbeddingRequest{
+		Model:  "small",
+		Inputs: []string{"a", "hello", "bb", "a"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"hello"}, {"a", "bb"}}, fake.requests)
+	require.Len(t, res.Data, 4)
+	for i, input := range []string{"a", "hello", "bb", "a"} {
+		assert.Equal(t, i, res.Data[i].Index)
+		assert.Equal(t, vectorFor(input, 0), res.Data[i].Embedding)
+	}
+	assert.Equal(t, 2, res.Usage.TotalTokens)
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 4}, embeddings.Stats())
+
+	// all hits, nothing sent and no usage
+	res, err = embeddings.EmbeddingsRequest(ctx, request.EmbeddingRequest{Model: "small", Inputs: []string{"bb", "a"}})
+	require.NoError(t, err)
+	assert.Len(t, fake.requests, 2)
+	assert.Equal(t, vectorFor("bb", 0), res.Data[0].Embedding)
+	assert.Equal(t, "small", res.Model)
+	assert.Zero(t, res.Usage.TotalTokens)
+
+	// dimensions and model are part of the key
+	embeddings.ResetStats()
+	res, err = embeddings.EmbeddingsRequest(ctx, request.EmbeddingRequest{
+		Model:           "small",
+		Input:           "a",
+		EmbeddingParams: request.EmbeddingParams{Dimensions: 256},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, vectorFor("a", 256), res.Data[0].Embedding)
+	_, err = embeddings.EmbeddingsRequest(ctx, request.EmbeddingRequest{Model: "large", Input: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, cache.Stats{Misses: 2}, embeddings.Stats())
+	assert.Len(t, fake.requests, 4)
+
+	_, err = embeddings.EmbeddingsRequest(ctx, request.EmbeddingRequest{Model: "small"})
+	assert.ErrorContains(t, err, "input cannot be empty")
+
+	fake.err = errors.New("rate limited")
+	_, err = embeddings.EmbeddingsRequest(ctx, request.EmbeddingRequest{Model: "small", Input: "new"})
+	assert.ErrorContains(t, err, "rate limited")
+}
+
+func TestEmbeddings_EmbeddingsBatch(t *testing.T) {
+	ctx := context.Background()
+	store, err := cache.NewDisk(t.TempDir())
+	require.NoError(t, err)
+	fake := &fakeClient{failText: "bad"}
+	embeddings := cache.NewEmbeddings(fake, store)
+	model := models.ModelMeta{ModelId: "small"}
+	options := request.EmbeddingBatchOptions{Params: request.EmbeddingParams{Dimensions: 8}}
+
+	res, err := embeddings.EmbeddingsBatch(ctx, model, []string{"one", "two", "bad"}, options)
+	require.NoError(t, err)
+	assert.Equal(t, []error{nil, nil, errors.New("input 2 failed")}, res.Errors)
+
+	inputs := []string{"two", "", "three", "bad", "one", "three"}
+	res, err = embeddings.EmbeddingsBatch(ctx, model, inputs, options)
+	require.NoError(t, err)
+	// failed inputs are not cached and are retried
+	assert.Equal(t, []string{"three", "bad"}, fake.requests[1])
+	require.Len(t, res.Data, len(inputs))
+	for i, input := range inputs {
+		assert.Equal(t, i, res.Data[i].Index)
+		switch input {
+		case "":
+			assert.ErrorContains(t, res.Errors[i], "input 1 cannot be empty")
+		case "bad":
+			assert.ErrorContains(t, res.Errors[i], "failed")
+			assert.Empty(t, res.Data[i].Embedding)
+		default:
+			assert.NoError(t, res.Errors[i])
+			assert.Equal(t, vectorFor(input, 8), res.Data[i].Embedding)
+		}
+	}
+	assert.Equal(t, 2, res.Usage.TotalTokens)
+	assert.Equal(t, cache.Stats{Hits: 2, Misses: 6}, embeddings.Stats())
+
+	_, err = embeddings.EmbeddingsBatch(ctx, model, nil, options)
+	assert.ErrorContains(t, err, "inputs cannot be empty")
+
+	_, err = embeddings.EmbeddingsBatch(ctx, model, []string{"x"}, request.EmbeddingBatchOptions{Params: request.EmbeddingParams{EncodingFormat: "int8"}})
+	assert.ErrorContains(t, err, "invalid request")
+}
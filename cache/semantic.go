@@ -0,0 +1,282 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+	"github.com/andrejsstepanovs/go-litellm/vectorindex"
+)
+
+const (
+	// DefaultSemanticThreshold is the minimum cosine similarity of a match.
+	DefaultSemanticThreshold = 0.95
+	DefaultSemanticEntries   = 1000
+)
+
+// SemanticEmbedder creates the question embeddings, *client.Litellm and *Embeddings implement it.
+type SemanticEmbedder interface {
+	Embeddings(ctx context.Context, model models.ModelMeta, inputText string) (response.EmbeddingResponse, error)
+}
+
+// SemanticMatch explains which cached entry answered a request.
+type SemanticMatch struct {
+	ID string
+	// Question is the cached user message that matched.
+	Question string
+	// Score is the cosine similarity between the questions.
+	Score     float64
+	Scope     string
+	CreatedAt time.Time
+	// Hits counts the times the entry was served, this one included.
+	Hits int
+}
+
+// Semantic answers paraphrased questions from earlier responses. The last user message is
+// embedded and compared with cached questions of the same scope, the completion model plus
+// a hash of the system prompt, tools, tool_choice and response_format. Earlier turns are not
+// compared, so it suits single turn questions such as an FAQ bot. Questions with images or
+// files are not cached, and when embedding fails the client is called without the cache.
+//
+// Hits are moderated first when the client is a Moderator, see Completions.
+//
+// Entries are evicted least recently used beyond MaxEntries and after TTL. Bypass and the
+// NoCache and NoStore controls of request.LiteLLMCache are honored like in Completions.
+type Semantic struct {
+	Client   CompletionClient
+	Embedder SemanticEmbedder
+	// Model is the embedding model.
+	Model models.ModelMeta
+	// Threshold defaults to DefaultSemanticThreshold.
+	Threshold float64
+	// MaxEntries defaults to DefaultSemanticEntries.
+	MaxEntries int
+	// TTL of entries, 0 keeps them until evicted.
+	TTL time.Duration
+
+	mu      sync.Mutex
+	index   *vectorindex.Index
+	entries map[string]*list.Element
+	order   *list.List
+	nextID  int64
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+type semanticEntry struct {
+	match     SemanticMatch
+	response  response.Response
+	expiresAt time.Time
+}
+
+func NewSemantic(client CompletionClient, embedder SemanticEmbedder, model models.ModelMeta) *Semantic {
+	return &Semantic{Client: client, Embedder: embedder, Model: model}
+}
+
+func (s *Semantic) Stats() Stats {
+	return Stats{Hits: s.hits.Load(), Misses: s.misses.Load()}
+}
+
+func (s *Semantic) ResetStats() {
+	s.hits.Store(0)
+	s.misses.Store(0)
+}
+
+// Len returns the number of cached entries.
+func (s *Semantic) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.order == nil {
+		return 0
+	}
+	return s.order.Len()
+}
+
+// Completion has the signature of Litellm.Completion. Cached responses have FromCache set.
+func (s *Semantic) Completion(ctx context.Context, req *request.Request) (response.Response, error) {
+	res, _, err := s.CompletionMatch(ctx, req)
+	return res, err
+}
+
+// CompletionMatch is Completion that also returns the matched entry, nil when the API was called.
+func (s *Semantic) CompletionMatch(ctx context.Context, req *request.Request) (response.Response, *SemanticMatch, error) {
+	if req == nil || req.Stream || bypassed(ctx) {
+		res, err := s.Client.Completion(ctx, req)
+		return res, nil, err
+	}
+	question := lastUserText(req.Messages)
+	if question == "" {
+		res, err := s.Client.Completion(ctx, req)
+		return res, nil, err
+	}
+	controls := request.LiteLLMCache{}
+	if req.Cache != nil {
+		controls = *req.Cache
+	}
+
+	embedded, err := s.Embedder.Embeddings(ctx, s.Model, question)
+	if err == nil && len(embedded.Data) == 0 {
+		err = fmt.Errorf("no embedding returned")
+	}
+	if err != nil {
+		log.Printf("Warning: semantic cache skipped, failed to embed question: %v", err)
+		res, err := s.Client.Completion(ctx, req)
+		return res, nil, err
+	}
+	vector := embedded.Data[0].Embedding
+	scope, err := SemanticScope(req)
+	if err != nil {
+		return response.Response{}, nil, err
+	}
+
+	if !controls.NoCache {
+		if res, match, ok := s.lookup(vector, scope); ok {
+			if err := moderateHit(ctx, s.Client, req); err != nil {
+				return response.Response{}, nil, err
+			}
+			s.hits.Add(1)
+			return res, match, nil
+		}
+	}
+	s.misses.Add(1)
+
+	res, err := s.Client.Completion(ctx, req)
+	if err != nil || controls.NoStore || len(res.Choices) == 0 {
+		return res, nil, err
+	}
+	if err := s.add(question, scope, vector, res); err != nil {
+		log.Printf("Warning: semantic cache store failed: %v", err)
+	}
+	return res, nil, nil
+}
+
+func (s *Semantic) lookup(vector response.Embedding, scope string) (response.Response, *SemanticMatch, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.index == nil {
+		return response.Response{}, nil, false
+	}
+
+	// expired entries are only dropped lazily, so look past them
+	results, err := s.index.Search(vector, len(s.entries), vectorindex.Where(map[string]string{"scope": scope}))
+	if err != nil {
+		return response.Response{}, nil, false
+	}
+	threshold := s.Threshold
+	if threshold == 0 {
+		threshold = DefaultSemanticThreshold
+	}
+	for _, result := range results {
+		if result.Score < threshold {
+			break
+		}
+		element, ok := s.entries[result.ID]
+		if !ok {
+			continue
+		}
+		entry := element.Value.(*semanticEntry)
+		if expired(entry.expiresAt) {
+			s.remove(element)
+			continue
+		}
+		s.order.MoveToFront(element)
+		entry.match.Hits++
+
+		match := entry.match
+		match.Score = result.Score
+		res := entry.response
+		res.FromCache = true
+		return res, &match, true
+	}
+	return response.Response{}, nil, false
+}
+
+func (s *Semantic) add(question, scope string, vector response.Embedding, res response.Response) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.index == nil {
+		index, err := vectorindex.New(vectorindex.METRIC_COSINE)
+		if err != nil {
+			return err
+		}
+		s.index, s.entries, s.order = index, make(map[string]*list.Element), list.New()
+	}
+
+	s.nextID++
+	id := strconv.FormatInt(s.nextID, 10)
+	if err := s.index.Add(id, vector, map[string]string{"scope": scope}); err != nil {
+		return fmt.Errorf("failed to cache response: %w", err)
+	}
+	entry := &semanticEntry{
+		match:     SemanticMatch{ID: id, Question: question, Scope: scope, CreatedAt: time.Now()},
+		response:  res,
+		expiresAt: expiry(s.TTL),
+	}
+	s.entries[id] = s.order.PushFront(entry)
+
+	maxEntries := s.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultSemanticEntries
+	}
+	for s.order.Len() > maxEntries {
+		s.remove(s.order.Back())
+	}
+	return nil
+}
+
+func (s *Semantic) remove(element *list.Element) {
+	id := element.Value.(*semanticEntry).match.ID
+	s.order.Remove(element)
+	delete(s.entries, id)
+	s.index.Delete(id)
+}
+
+// SemanticScope separates cached questions by completion model, system prompt, tools,
+// tool_choice and response_format.
+func SemanticScope(req *request.Request) (string, error) {
+	hash := sha256.New()
+	for _, message := range req.Messages {
+		if message.Role == request.ROLE_SYSTEM {
+			hash.Write([]byte(message.Contents.String()))
+			hash.Write([]byte{0})
+		}
+	}
+	shape, err := json.Marshal([]any{req.Tools, req.ToolChoice, req.ResponseFormat})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	hash.Write(shape)
+	return string(req.Model) + ":" + hex.EncodeToString(hash.Sum(nil))[:16], nil
+}
+
+func lastUserText(messages request.Messages) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != request.ROLE_USER {
+			continue
+		}
+		// images or files would collapse onto the key of their text, such questions are not cached
+		texts := make([]string, 0, len(messages[i].Contents))
+		for _, part := range messages[i].Contents {
+			if part.Type != "text" {
+				return ""
+			}
+			texts = append(texts, part.Text)
+		}
+		return strings.Join(texts, " ")
+	}
+	return ""
+}
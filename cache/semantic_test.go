@@ -0,0 +1,266 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/cache"
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+var (
+	_ cache.SemanticEmbedder = (*client.Litellm)(nil)
+	_ cache.SemanticEmbedder = (*cache.Embeddings)(nil)
+)
+
+// tableEmbedder returns fixed vectors, paraphrases point in almost the same direction.
+type tableEmbedder struct {
+	vectors map[string]response.Embedding
+	calls   int
+	err     error
+}
+
+func (e *tableEmbedder) Embeddings(_ context.Context, _ models.ModelMeta, inputText string) (response.EmbeddingResponse, error) {
+	e.calls++
+	if e.err != nil {
+		return response.EmbeddingResponse{}, e.err
+	}
+	return response.EmbeddingResponse{Data: []response.EmbeddingData{{Embedding: e.vectors[inputText]}}}, nil
+}
+
+func faqEmbedder() *tableEmbedder {
+	return &tableEmbedder{vectors: map[string]response.Embedding{
+		"How do I reset my password?":      {1, 0, 0},
+		"how can i reset the password":     {0.99, 0.05, 0},
+		"What are your opening hours?":     {0, 1, 0},
+		"When does the shop open?":         {0.1, 0.97, 0},
+		"Where can I download the invoice": {0, 0, 1},
+	}}
+}
+
+func faqRequest(system, question string) *request.Request {
+	req := request.NewRequest(models.ModelMeta{ModelId: "gpt-4o-mini"})
+	req.SetMessages(request.Messages{request.SystemMessageSimple(system), request.UserMessageSimple(question)})
+	return req
+}
+
+func TestSemantic_CompletionMatch(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	semantic := cache.NewSemantic(fake, faqEmbedder(), models.ModelMeta{ModelId: "text-embedding-3-small"})
+
+	res, match, err := semantic.CompletionMatch(ctx, faqRequest("FAQ bot", "How do I reset my password?"))
+	require.NoError(t, err)
+	assert.Nil(t, match)
+	assert.False(t, res.FromCache)
+
+	res, match, err = semantic.CompletionMatch(ctx, faqRequest("FAQ bot", "how can i reset the password"))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.True(t, res.FromCache)
+	assert.Equal(t, "answer How do I reset my password?", res.String())
+	assert.Equal(t, "How do I reset my password?", match.Question)
+	assert.InDelta(t, 0.9987, match.Score, 0.001)
+	assert.Equal(t, 1, match.Hits)
+	scope, err := cache.SemanticScope(faqRequest("FAQ bot", "x"))
+	require.NoError(t, err)
+	assert.Equal(t, scope, match.Scope)
+
+	// below the threshold
+	_, err = semantic.Completion(ctx, faqRequest("FAQ bot", "What are your opening hours?"))
+	require.NoError(t, err)
+	res, match, err = semantic.CompletionMatch(ctx, faqRequest("FAQ bot", "When does the shop open?"))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, "answer What are your opening hours?", res.String())
+
+	semantic.Threshold = 0.999
+	_, match, err = semantic.CompletionMatch(ctx, faqRequest("FAQ bot", "When does the shop open?"))
+	require.NoError(t, err)
+	assert.Nil(t, match)
+
+	// another system prompt is another scope
+	semantic.Threshold = 0
+	_, match, err = semantic.CompletionMatch(ctx, faqRequest("Support bot", "How do I reset my password?"))
+	require.NoError(t, err)
+	assert.Nil(t, match)
+
+	assert.Equal(t, cache.Stats{Hits: 2, Misses: 4}, semantic.Stats())
+	assert.Equal(t, 4, fake.calls)
+	assert.Equal(t, 4, semantic.Len())
+}
+
+func TestSemantic_Eviction(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	semantic := cache.NewSemantic(fake, faqEmbedder(), models.ModelMeta{ModelId: "embed"})
+	semantic.MaxEntries = 2
+
+	for _, question := range []string{"How do I reset my password?", "What are your opening hours?"} {
+		_, err := semantic.Completion(ctx, faqRequest("FAQ", question))
+		require.NoError(t, err)
+	}
+	// using the password entry makes the opening hours entry the oldest
+	_, match, err := semantic.CompletionMatch(ctx, faqRequest("FAQ", "how can i reset the password"))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+
+	_, err = semantic.Completion(ctx, faqRequest("FAQ", "Where can I download the invoice"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, semantic.Len())
+
+	// probe without storing, a stored miss would evict again
+	probe := request.LiteLLMCache{NoStore: true}
+	_, match, err = semantic.CompletionMatch(ctx, faqRequest("FAQ", "When does the shop open?").SetCache(probe))
+	require.NoError(t, err)
+	assert.Nil(t, match)
+	_, match, err = semantic.CompletionMatch(ctx, faqRequest("FAQ", "How do I reset my password?").SetCache(probe))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, 2, match.Hits)
+
+	// expired entries are dropped on lookup
+	semantic.TTL = time.Millisecond
+	_, err = semantic.Completion(ctx, faqRequest("TTL", "What are your opening hours?"))
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, match, err = semantic.CompletionMatch(ctx, faqRequest("TTL", "What are your opening hours?"))
+	require.NoError(t, err)
+	assert.Nil(t, match)
+}
+
+func TestSemantic_Controls(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	embedder := faqEmbedder()
+	semantic := cache.NewSemantic(fake, embedder, models.ModelMeta{ModelId: "embed"})
+
+	_, err := semantic.Completion(ctx, faqRequest("FAQ", "How do I reset my password?").SetCache(request.LiteLLMCache{NoStore: true}))
+	require.NoError(t, err)
+	assert.Equal(t, 0, semantic.Len())
+
+	_, err = semantic.Completion(cache.Bypass(ctx), faqRequest("FAQ", "How do I reset my password?"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, embedder.calls)
+
+	// embedding failures fall through to the client
+	embedder.err = errors.New("embedding model down")
+	res, err := semantic.Completion(ctx, faqRequest("FAQ", "How do I reset my password?"))
+	require.NoError(t, err)
+	assert.Equal(t, "answer How do I reset my password?", res.String())
+	embedder.err = nil
+	res, err = semantic.Completion(ctx, faqRequest("FAQ", "unknown question"))
+	require.NoError(t, err)
+	assert.Equal(t, "answer unknown question", res.String())
+	assert.Equal(t, 0, semantic.Len())
+	assert.Equal(t, 4, fake.calls)
+}
+
+func TestSemantic_SkipsExpiredMatch(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	semantic := cache.NewSemantic(fake, faqEmbedder(), models.ModelMeta{ModelId: "embed"})
+
+	// a strict threshold keeps both paraphrases as separate entries
+	semantic.Threshold = 0.999
+	_, err := semantic.Completion(ctx, faqRequest("FAQ", "how can i reset the password"))
+	require.NoError(t, err)
+	semantic.TTL = time.Millisecond
+	_, err = semantic.Completion(ctx, faqRequest("FAQ", "How do I reset my password?"))
+	require.NoError(t, err)
+	require.Equal(t, 2, semantic.Len())
+	time.Sleep(5 * time.Millisecond)
+
+	semantic.Threshold = 0
+	probe := request.LiteLLMCache{NoStore: true}
+	res, match, err := semantic.CompletionMatch(ctx, faqRequest("FAQ", "How do I reset my password?").SetCache(probe))
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, "how can i reset the password", match.Question)
+	assert.Equal(t, "answer how can i reset the password", res.String())
+	assert.Equal(t, 1, semantic.Len())
+}
+
+func TestSemantic_SkipsNonTextParts(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	embedder := faqEmbedder()
+	semantic := cache.NewSemantic(fake, embedder, models.ModelMeta{ModelId: "embed"})
+
+	req := request.NewRequest(models.ModelMeta{ModelId: "gpt-4o-mini"})
+	req.SetMessages(request.Messages{request.UserMessageImage("How do I reset my password?", request.ImageUrl{URL: "https://example.com/a.png"})})
+	for range 2 {
+		_, match, err := semantic.CompletionMatch(ctx, req)
+		require.NoError(t, err)
+		assert.Nil(t, match)
+	}
+	assert.Equal(t, 0, semantic.Len())
+	assert.Equal(t, 0, embedder.calls)
+	assert.Equal(t, 2, fake.calls)
+}
+
+func TestSemantic_ScopeIncludesToolsAndFormat(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	semantic := cache.NewSemantic(fake, faqEmbedder(), models.ModelMeta{ModelId: "embed"})
+
+	_, err := semantic.Completion(ctx, faqRequest("FAQ", "How do I reset my password?"))
+	require.NoError(t, err)
+
+	withTools := faqRequest("FAQ", "How do I reset my password?").
+		SetAvailableTools(request.LLMCallTools{{Type: "function", Function: &request.LLMCallToolFunction{Name: "reset_password"}}})
+	_, match, err := semantic.CompletionMatch(ctx, withTools)
+	require.NoError(t, err)
+	assert.Nil(t, match, "tools change the scope")
+
+	withFormat := faqRequest("FAQ", "How do I reset my password?")
+	withFormat.SetJSONMode()
+	_, match, err = semantic.CompletionMatch(ctx, withFormat)
+	require.NoError(t, err)
+	assert.Nil(t, match, "response_format changes the scope")
+
+	_, match, err = semantic.CompletionMatch(ctx, faqRequest("FAQ", "how can i reset the password"))
+	require.NoError(t, err)
+	assert.NotNil(t, match)
+	assert.Equal(t, 3, semantic.Len())
+}
+
+func TestSemantic_ModeratesHits(t *testing.T) {
+	ctx := context.Background()
+	fake := &moderatedCompleter{}
+	semantic := cache.NewSemantic(fake, faqEmbedder(), models.ModelMeta{ModelId: "embed"})
+
+	_, err := semantic.Completion(ctx, faqRequest("FAQ", "How do I reset my password?"))
+	require.NoError(t, err)
+	fake.err = errors.New("blocked by moderation: hate (0.90)")
+	_, match, err := semantic.CompletionMatch(ctx, faqRequest("FAQ", "how can i reset the password"))
+	require.EqualError(t, err, "blocked by moderation: hate (0.90)")
+	assert.Nil(t, match)
+	assert.Equal(t, 1, fake.moderated)
+}
+
+func TestSemantic_StoreFailureKeepsResponse(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeCompleter{}
+	embedder := faqEmbedder()
+	embedder.vectors["short"] = response.Embedding{1, 0}
+	semantic := cache.NewSemantic(fake, embedder, models.ModelMeta{ModelId: "embed"})
+
+	_, err := semantic.Completion(ctx, faqRequest("FAQ", "How do I reset my password?"))
+	require.NoError(t, err)
+
+	// the index rejects vectors of another size, the paid response is still returned
+	res, match, err := semantic.CompletionMatch(ctx, faqRequest("FAQ", "short"))
+	require.NoError(t, err)
+	assert.Nil(t, match)
+	assert.Equal(t, "answer short", res.String())
+	assert.Equal(t, 1, semantic.Len())
+}
@@ -0,0 +1,42 @@
+// Package cache stores embeddings and responses so repeated calls skip the API.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Store is a byte value store. Implementations must be safe for concurrent use.
+// Other backends (SQLite, Redis) can be plugged in by implementing it.
+type Store interface {
+	// Get returns the value and whether it was found and not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value. A ttl of 0 keeps the value until it is evicted or deleted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+// Stats counts cache lookups.
+type Stats struct {
+	Hits   int64
+	Misses int64
+}
+
+// HitRate returns hits / lookups, 0 without lookups.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func expired(expiresAt time.Time) bool {
+	return !expiresAt.IsZero() && time.Now().After(expiresAt)
+}
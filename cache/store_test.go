@@ -0,0 +1,139 @@
+package cache_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/cache"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewMemory(2)
+
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, store.Set(ctx, "b", []byte("2"), 0))
+
+	// reading a makes b the least recently used entry
+	value, found, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+
+	require.NoError(t, store.Set(ctx, "c", []byte("3"), 0))
+	assert.Equal(t, 2, store.Len())
+	_, found, _ = store.Get(ctx, "b")
+	assert.False(t, found)
+	_, found, _ = store.Get(ctx, "c")
+	assert.True(t, found)
+
+	require.NoError(t, store.Delete(ctx, "c"))
+	_, found, _ = store.Get(ctx, "c")
+	assert.False(t, found)
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestStores_TTL(t *testing.T) {
+	disk, err := cache.NewDisk(t.TempDir())
+	require.NoError(t, err)
+	db, err := cache.NewBolt(filepath.Join(t.TempDir(), "cache.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	for name, store := range map[string]cache.Store{"memory": cache.NewMemory(0), "disk": disk, "bolt": db} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			require.NoError(t, store.Set(ctx, "short", []byte("x"), time.Millisecond))
+			require.NoError(t, store.Set(ctx, "forever", []byte("y"), 0))
+			time.Sleep(5 * time.Millisecond)
+
+			_, found, err := store.Get(ctx, "short")
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			value, found, err := store.Get(ctx, "forever")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, []byte("y"), value)
+		})
+	}
+}
+
+func TestDisk(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := cache.NewDisk(dir)
+	require.NoError(t, err)
+
+	_, found, err := store.Get(ctx, "embedding:model/with/slashes")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, store.Set(ctx, "embedding:model/with/slashes", []byte("vector"), 0))
+	require.NoError(t, store.Set(ctx, "empty", []byte{}, 0))
+
+	// a second store on the same directory sees the values, like after a restart
+	reopened, err := cache.NewDisk(dir)
+	require.NoError(t, err)
+	value, found, err := reopened.Get(ctx, "embedding:model/with/slashes")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("vector"), value)
+
+	value, found, err = reopened.Get(ctx, "empty")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Empty(t, value)
+
+	require.NoError(t, reopened.Delete(ctx, "embedding:model/with/slashes"))
+	require.NoError(t, reopened.Delete(ctx, "never stored"))
+	_, found, _ = store.Get(ctx, "embedding:model/with/slashes")
+	assert.False(t, found)
+}
+
+func TestBolt(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.db")
+	store, err := cache.NewBolt(path)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Set(ctx, "embedding:a", []byte("vector"), 0))
+	require.NoError(t, store.Set(ctx, "empty", []byte{}, 0))
+	for _, key := range []string{"old-1", "old-2", "old-3"} {
+		require.NoError(t, store.Set(ctx, key, []byte("x"), time.Millisecond))
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	removed, err := store.DeleteExpired(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, removed)
+	require.NoError(t, store.Close())
+
+	// values survive a restart
+	reopened, err := cache.NewBolt(path)
+	require.NoError(t, err)
+	defer func() { _ = reopened.Close() }()
+	value, found, err := reopened.Get(ctx, "embedding:a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("vector"), value)
+
+	value, found, err = reopened.Get(ctx, "empty")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Empty(t, value)
+
+	require.NoError(t, reopened.Delete(ctx, "embedding:a"))
+	_, found, _ = reopened.Get(ctx, "embedding:a")
+	assert.False(t, found)
+}
+
+func TestStats_HitRate(t *testing.T) {
+	assert.Equal(t, 0.0, cache.Stats{}.HitRate())
+	assert.Equal(t, 0.75, cache.Stats{Hits: 3, Misses: 1}.HitRate())
+}
@@ -0,0 +1,175 @@
+// Package chunker splits documents into token limited chunks for embedding.
+// Every chunk keeps its byte offsets in the source, so search hits can be mapped back.
+package chunker
+
+import (
+	"context"
+	"fmt"
+)
+
+type Strategy string
+
+const (
+	// STRATEGY_FIXED packs words up to MaxTokens.
+	STRATEGY_FIXED Strategy = "fixed"
+	// STRATEGY_RECURSIVE splits at the first of Separators that yields pieces under MaxTokens.
+	STRATEGY_RECURSIVE Strategy = "recursive"
+	// STRATEGY_SENTENCE packs whole sentences, falling back to words for very long sentences.
+	STRATEGY_SENTENCE Strategy = "sentence"
+	// STRATEGY_MARKDOWN never mixes sections under different headings and records the heading path.
+	STRATEGY_MARKDOWN Strategy = "markdown"
+
+	DefaultMaxTokens = 512
+)
+
+// DefaultSeparators are tried in order by the recursive and markdown strategies.
+var DefaultSeparators = []string{"\n\n", "\n", ". ", " "}
+
+// Options configures Split.
+type Options struct {
+	// Strategy defaults to STRATEGY_RECURSIVE.
+	Strategy Strategy
+	// MaxTokens per chunk. Defaults to DefaultMaxTokens. A single word over the limit becomes its own chunk.
+	MaxTokens int
+	// Overlap is the number of tokens repeated from the end of the previous chunk.
+	Overlap int
+	// Separators for the recursive and markdown strategies. Defaults to DefaultSeparators.
+	Separators []string
+	// Counter defaults to Estimator. Split calls it once per span and word, so a remote counter is slow.
+	Counter TokenCounter
+}
+
+func (o Options) withDefaults() (Options, error) {
+	if o.Strategy == "" {
+		o.Strategy = STRATEGY_RECURSIVE
+	}
+	if o.MaxTokens <= 0 {
+		o.MaxTokens = DefaultMaxTokens
+	}
+	if len(o.Separators) == 0 {
+		o.Separators = DefaultSeparators
+	}
+	if o.Counter == nil {
+		o.Counter = Estimator{}
+	}
+	if o.Overlap < 0 || o.Overlap >= o.MaxTokens {
+		return o, fmt.Errorf("overlap %d must be between 0 and max tokens %d", o.Overlap, o.MaxTokens)
+	}
+	switch o.Strategy {
+	case STRATEGY_FIXED, STRATEGY_RECURSIVE, STRATEGY_SENTENCE, STRATEGY_MARKDOWN:
+		return o, nil
+	}
+	return o, fmt.Errorf("unknown chunking strategy %q", o.Strategy)
+}
+
+// Chunk is a part of the source text. Text equals source[Start:End].
+type Chunk struct {
+	Index  int
+	Text   string
+	Start  int
+	End    int
+	Tokens int
+	// Headings is the markdown heading path of the chunk, outermost first.
+	Headings []string
+}
+
+// Split cuts text into chunks according to options.
+func Split(ctx context.Context, text string, options Options) ([]Chunk, error) {
+	options, err := options.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	s := splitter{ctx: ctx, text: text, options: options}
+	sections := []section{{start: 0, end: len(text)}}
+	if options.Strategy == STRATEGY_MARKDOWN {
+		sections = markdownSections(text)
+	}
+
+	chunks := make([]Chunk, 0)
+	for _, sec := range sections {
+		var units []span
+		switch options.Strategy {
+		case STRATEGY_FIXED:
+			units = wordSpans(text, sec.start, sec.end)
+		case STRATEGY_SENTENCE:
+			units, err = s.sentenceUnits(sec.start, sec.end)
+		default:
+			units, err = s.recursiveUnits(sec.start, sec.end, options.Separators)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		packed, err := s.pack(units)
+		if err != nil {
+			return nil, err
+		}
+		for _, chunk := range packed {
+			chunk.Index = len(chunks)
+			chunk.Headings = sec.headings
+			chunks = append(chunks, chunk)
+		}
+	}
+
+	return chunks, nil
+}
+
+// span is a trimmed range of the source text, tokens is -1 until counted.
+type span struct {
+	start, end int
+	tokens     int
+}
+
+type splitter struct {
+	ctx     context.Context
+	text    string
+	options Options
+}
+
+func (s *splitter) count(start, end int) (int, error) {
+	return s.options.Counter.CountTokens(s.ctx, s.text[start:end])
+}
+
+// pack joins consecutive units into chunks of at most MaxTokens. The token count
+// of a chunk is the sum of its units, separators between units are not counted.
+func (s *splitter) pack(units []span) ([]Chunk, error) {
+	for i := range units {
+		if units[i].tokens >= 0 {
+			continue
+		}
+		tokens, err := s.count(units[i].start, units[i].end)
+		if err != nil {
+			return nil, err
+		}
+		units[i].tokens = tokens
+	}
+
+	chunks := make([]Chunk, 0)
+	first, tokens := 0, 0
+	emit := func(last int) {
+		start, end := units[first].start, units[last].end
+		chunks = append(chunks, Chunk{Text: s.text[start:end], Start: start, End: end, Tokens: tokens})
+	}
+
+	for i, unit := range units {
+		if i > first && tokens+unit.tokens > s.options.MaxTokens {
+			emit(i - 1)
+			// repeat trailing units of the previous chunk that fit the overlap and leave room for unit
+			next := i
+			overlap := 0
+			for next-1 > first && overlap+units[next-1].tokens <= s.options.Overlap &&
+				overlap+units[next-1].tokens+unit.tokens <= s.options.MaxTokens {
+				next--
+				overlap += units[next].tokens
+			}
+			first, tokens = next, overlap
+		}
+		tokens += unit.tokens
+	}
+	if len(units) > 0 {
+		emit(len(units) - 1)
+	}
+
+	return chunks, nil
+}
@@ -0,0 +1,130 @@
+package chunker_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/chunker"
+)
+
+// wordCounter counts words, so expectations are easy to read.
+type wordCounter struct{}
+
+func (wordCounter) CountTokens(_ context.Context, text string) (int, error) {
+	return len(strings.Fields(text)), nil
+}
+
+func chunkTexts(chunks []chunker.Chunk) []string {
+	texts := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		texts = append(texts, chunk.Text)
+	}
+	return texts
+}
+
+func assertOffsets(t *testing.T, text string, chunks []chunker.Chunk) {
+	t.Helper()
+	for i, chunk := range chunks {
+		assert.Equal(t, i, chunk.Index)
+		assert.Equal(t, text[chunk.Start:chunk.End], chunk.Text)
+	}
+}
+
+func TestSplit_Fixed(t *testing.T) {
+	text := "one two three four five six seven"
+
+	chunks, err := chunker.Split(context.Background(), text, chunker.Options{Strategy: chunker.STRATEGY_FIXED, MaxTokens: 3, Counter: wordCounter{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one two three", "four five six", "seven"}, chunkTexts(chunks))
+	assert.Equal(t, 3, chunks[0].Tokens)
+	assertOffsets(t, text, chunks)
+
+	chunks, err = chunker.Split(context.Background(), text, chunker.Options{Strategy: chunker.STRATEGY_FIXED, MaxTokens: 3, Overlap: 1, Counter: wordCounter{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one two three", "three four five", "five six seven"}, chunkTexts(chunks))
+	assert.Equal(t, 8, chunks[1].Start)
+	assertOffsets(t, text, chunks)
+}
+
+func TestSplit_Recursive(t *testing.T) {
+	text := "Intro line.\n\nFirst paragraph has five words.\nSecond line of it. With two sentences here.\n\nEnd."
+
+	chunks, err := chunker.Split(context.Background(), text, chunker.Options{MaxTokens: 6, Counter: wordCounter{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Intro line.",
+		"First paragraph has five words.",
+		"Second line of it.",
+		"With two sentences here.\n\nEnd.",
+	}, chunkTexts(chunks))
+	assertOffsets(t, text, chunks)
+
+	// everything fits
+	chunks, err = chunker.Split(context.Background(), "  short text \n", chunker.Options{})
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	assert.Equal(t, chunker.Chunk{Text: "short text", Start: 2, End: 12, Tokens: 3}, chunks[0])
+
+	chunks, err = chunker.Split(context.Background(), " \n ", chunker.Options{})
+	require.NoError(t, err)
+	assert.Empty(t, chunks)
+}
+
+func TestSplit_Sentence(t *testing.T) {
+	text := `He said "stop." Then — nothing! Was it over? A very long sentence that has far too many words in it`
+
+	chunks, err := chunker.Split(context.Background(), text, chunker.Options{Strategy: chunker.STRATEGY_SENTENCE, MaxTokens: 5, Counter: wordCounter{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`He said "stop."`,
+		"Then — nothing!",
+		// words of a sentence over the limit are packed like any other unit
+		"Was it over? A very",
+		"long sentence that has far",
+		"too many words in it",
+	}, chunkTexts(chunks))
+	assertOffsets(t, text, chunks)
+
+	chunks, err = chunker.Split(context.Background(), "Version 1.2 is out.\n\nNew paragraph without stop", chunker.Options{Strategy: chunker.STRATEGY_SENTENCE, MaxTokens: 4, Counter: wordCounter{}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Version 1.2 is out.", "New paragraph without stop"}, chunkTexts(chunks))
+}
+
+func TestSplit_Markdown(t *testing.T) {
+	text := "Preface text.\n" +
+		"# Guide\n" +
+		"Welcome.\n" +
+		"## Install ##\n" +
+		"Run the installer.\n" +
+		"```sh\n# not a heading\n```\n" +
+		"### C#\n" +
+		"Use dotnet.\n" +
+		"## Usage\n" +
+		"Call it.\n"
+
+	chunks, err := chunker.Split(context.Background(), text, chunker.Options{Strategy: chunker.STRATEGY_MARKDOWN, MaxTokens: 100})
+	require.NoError(t, err)
+	require.Len(t, chunks, 5)
+	assertOffsets(t, text, chunks)
+
+	assert.Equal(t, "Preface text.", chunks[0].Text)
+	assert.Nil(t, chunks[0].Headings)
+	assert.Equal(t, "# Guide\nWelcome.", chunks[1].Text)
+	assert.Equal(t, []string{"Guide"}, chunks[1].Headings)
+	assert.Equal(t, "## Install ##\nRun the installer.\n```sh\n# not a heading\n```", chunks[2].Text)
+	assert.Equal(t, []string{"Guide", "Install"}, chunks[2].Headings)
+	assert.Equal(t, []string{"Guide", "Install", "C#"}, chunks[3].Headings)
+	assert.Equal(t, []string{"Guide", "Usage"}, chunks[4].Headings)
+}
+
+func TestSplit_Options(t *testing.T) {
+	_, err := chunker.Split(context.Background(), "text", chunker.Options{MaxTokens: 10, Overlap: 10})
+	assert.ErrorContains(t, err, "overlap 10 must be between 0 and max tokens 10")
+
+	_, err = chunker.Split(context.Background(), "text", chunker.Options{Strategy: "semantic"})
+	assert.ErrorContains(t, err, `unknown chunking strategy "semantic"`)
+}
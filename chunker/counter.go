@@ -0,0 +1,95 @@
+package chunker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// TokenCounter counts the tokens of a piece of text.
+type TokenCounter interface {
+	CountTokens(ctx context.Context, text string) (int, error)
+}
+
+// Estimator counts tokens locally with request.EstimateTokens. It is fast but approximate.
+type Estimator struct{}
+
+func (Estimator) CountTokens(_ context.Context, text string) (int, error) {
+	return request.EstimateTokens(text), nil
+}
+
+// TokenCounterClient is the token counting part of *client.Litellm.
+type TokenCounterClient interface {
+	TokenCounter(ctx context.Context, req *request.TokenCounterRequest) (*response.TokenCounterResponse, error)
+}
+
+// LitellmCounter counts tokens with the tokenizer of Model through LiteLLM /utils/token_counter.
+// The text is counted as a user message and the message overhead, measured once with an
+// empty message, is subtracted. Results are cached per text, so repeated pieces are counted once.
+// Every uncached piece costs one HTTP round trip: Split counts each candidate span, and
+// STRATEGY_FIXED or spans over MaxTokens fall back to counting word by word. Prefer Estimator
+// for large documents and use LitellmCounter when exact counts matter.
+type LitellmCounter struct {
+	Client TokenCounterClient
+	Model  models.ModelID
+
+	mu       sync.Mutex
+	cache    map[string]int
+	overhead *int
+}
+
+func NewLitellmCounter(client TokenCounterClient, model models.ModelID) *LitellmCounter {
+	return &LitellmCounter{Client: client, Model: model, cache: make(map[string]int)}
+}
+
+func (c *LitellmCounter) CountTokens(ctx context.Context, text string) (int, error) {
+	if text == "" {
+		return 0, nil
+	}
+
+	c.mu.Lock()
+	if c.cache == nil {
+		c.cache = make(map[string]int)
+	}
+	count, cached := c.cache[text]
+	overhead := c.overhead
+	c.mu.Unlock()
+	if cached {
+		return count, nil
+	}
+
+	if overhead == nil {
+		empty, err := c.count(ctx, "")
+		if err != nil {
+			return 0, err
+		}
+		overhead = &empty
+	}
+
+	total, err := c.count(ctx, text)
+	if err != nil {
+		return 0, err
+	}
+	count = max(1, total-*overhead)
+
+	c.mu.Lock()
+	c.cache[text] = count
+	c.overhead = overhead
+	c.mu.Unlock()
+	return count, nil
+}
+
+func (c *LitellmCounter) count(ctx context.Context, text string) (int, error) {
+	res, err := c.Client.TokenCounter(ctx, &request.TokenCounterRequest{
+		Model:    c.Model,
+		Messages: request.Messages{request.UserMessageSimple(text)},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tokens: %w", err)
+	}
+	return int(res.TotalTokens), nil
+}
@@ -0,0 +1,72 @@
+package chunker_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/chunker"
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+var _ chunker.TokenCounterClient = (*client.Litellm)(nil)
+
+// fakeTokenClient counts words plus a message overhead of 3 tokens.
+type fakeTokenClient struct {
+	calls int
+	err   error
+}
+
+func (f *fakeTokenClient) TokenCounter(_ context.Context, req *request.TokenCounterRequest) (*response.TokenCounterResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	text := req.Messages[0].Contents[0].Text
+	return &response.TokenCounterResponse{TotalTokens: float64(3 + len(strings.Fields(text)))}, nil
+}
+
+func TestEstimator(t *testing.T) {
+	count, err := chunker.Estimator{}.CountTokens(context.Background(), "12345678")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestLitellmCounter(t *testing.T) {
+	fake := &fakeTokenClient{}
+	counter := chunker.NewLitellmCounter(fake, "gpt-4o")
+
+	count, err := counter.CountTokens(context.Background(), "two words")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	// overhead and text
+	assert.Equal(t, 2, fake.calls)
+
+	count, err = counter.CountTokens(context.Background(), "two words")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 2, fake.calls)
+
+	count, err = counter.CountTokens(context.Background(), "three more words")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 3, fake.calls)
+
+	count, err = counter.CountTokens(context.Background(), "")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	chunks, err := chunker.Split(context.Background(), "a b c d e", chunker.Options{Strategy: chunker.STRATEGY_FIXED, MaxTokens: 2, Counter: counter})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a b", "c d", "e"}, chunkTexts(chunks))
+
+	fake.err = errors.New("proxy down")
+	_, err = chunker.Split(context.Background(), "new text", chunker.Options{Counter: counter})
+	assert.ErrorContains(t, err, "failed to count tokens: proxy down")
+}
@@ -0,0 +1,214 @@
+package chunker
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// recursiveUnits returns pieces under MaxTokens, splitting at the first separator
+// found in text and recursing with the remaining separators for pieces that are still too long.
+// Separators stay with the preceding piece. Without separators left, pieces fall back to words.
+func (s *splitter) recursiveUnits(start, end int, separators []string) ([]span, error) {
+	start, end = trimSpan(s.text, start, end)
+	if start >= end {
+		return nil, nil
+	}
+
+	tokens, err := s.count(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if tokens <= s.options.MaxTokens {
+		return []span{{start: start, end: end, tokens: tokens}}, nil
+	}
+	if len(separators) == 0 {
+		return wordSpans(s.text, start, end), nil
+	}
+
+	sep := separators[0]
+	units := make([]span, 0)
+	for pos := start; pos < end; {
+		next := end
+		if i := strings.Index(s.text[pos:end], sep); i >= 0 {
+			next = pos + i + len(sep)
+		}
+		pieces, err := s.recursiveUnits(pos, next, separators[1:])
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, pieces...)
+		pos = next
+	}
+	return units, nil
+}
+
+// sentenceUnits returns sentences, sentences over MaxTokens are split into words.
+func (s *splitter) sentenceUnits(start, end int) ([]span, error) {
+	units := make([]span, 0)
+	for _, sentence := range sentenceSpans(s.text, start, end) {
+		tokens, err := s.count(sentence.start, sentence.end)
+		if err != nil {
+			return nil, err
+		}
+		if tokens > s.options.MaxTokens {
+			units = append(units, wordSpans(s.text, sentence.start, sentence.end)...)
+			continue
+		}
+		sentence.tokens = tokens
+		units = append(units, sentence)
+	}
+	return units, nil
+}
+
+// sentenceSpans ends sentences after ., !, ? or … (and closing quotes or brackets)
+// followed by whitespace, and at blank lines.
+func sentenceSpans(text string, start, end int) []span {
+	spans := make([]span, 0)
+	sentenceStart := start
+	for pos := start; pos < end; {
+		r, size := utf8.DecodeRuneInString(text[pos:end])
+		pos += size
+
+		cut := -1
+		switch {
+		case r == '.' || r == '!' || r == '?' || r == '…':
+			after := pos
+			for after < end {
+				closing, closingSize := utf8.DecodeRuneInString(text[after:end])
+				if !strings.ContainsRune(`"')]»”’`, closing) {
+					break
+				}
+				after += closingSize
+			}
+			if after == end || isSpaceAt(text, after, end) {
+				cut = after
+			}
+		case r == '\n' && pos < end && text[pos] == '\n':
+			cut = pos
+		}
+		if cut < 0 {
+			continue
+		}
+
+		if a, b := trimSpan(text, sentenceStart, cut); a < b {
+			spans = append(spans, span{start: a, end: b, tokens: -1})
+		}
+		sentenceStart, pos = cut, cut
+	}
+	if a, b := trimSpan(text, sentenceStart, end); a < b {
+		spans = append(spans, span{start: a, end: b, tokens: -1})
+	}
+	return spans
+}
+
+// wordSpans returns the whitespace separated words between start and end.
+func wordSpans(text string, start, end int) []span {
+	spans := make([]span, 0)
+	wordStart := -1
+	for pos := start; pos < end; {
+		r, size := utf8.DecodeRuneInString(text[pos:end])
+		if unicode.IsSpace(r) {
+			if wordStart >= 0 {
+				spans = append(spans, span{start: wordStart, end: pos, tokens: -1})
+				wordStart = -1
+			}
+		} else if wordStart < 0 {
+			wordStart = pos
+		}
+		pos += size
+	}
+	if wordStart >= 0 {
+		spans = append(spans, span{start: wordStart, end: end, tokens: -1})
+	}
+	return spans
+}
+
+func isSpaceAt(text string, pos, end int) bool {
+	r, _ := utf8.DecodeRuneInString(text[pos:end])
+	return unicode.IsSpace(r)
+}
+
+// trimSpan moves start and end inwards past whitespace.
+func trimSpan(text string, start, end int) (int, int) {
+	trimmed := strings.TrimLeftFunc(text[start:end], unicode.IsSpace)
+	start = end - len(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	return start, start + len(trimmed)
+}
+
+// section is a part of a markdown document under one heading.
+type section struct {
+	start, end int
+	headings   []string
+}
+
+// markdownSections splits text at ATX headings ("# Title") outside fenced code blocks.
+// Text before the first heading is a section without headings.
+func markdownSections(text string) []section {
+	type heading struct {
+		level int
+		title string
+	}
+
+	sections := make([]section, 0)
+	stack := make([]heading, 0)
+	current := section{start: 0}
+	inFence := false
+
+	for pos := 0; pos < len(text); {
+		lineEnd := strings.IndexByte(text[pos:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(text)
+		} else {
+			lineEnd += pos
+		}
+		line := text[pos:lineEnd]
+
+		trimmed := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if level, title, ok := parseHeading(trimmed); ok && !inFence && len(line)-len(trimmed) <= 3 {
+			if pos > current.start {
+				current.end = pos
+				sections = append(sections, current)
+			}
+			for len(stack) > 0 && stack[len(stack)-1].level >= level {
+				stack = stack[:len(stack)-1]
+			}
+			stack = append(stack, heading{level: level, title: title})
+
+			headings := make([]string, len(stack))
+			for i, h := range stack {
+				headings[i] = h.title
+			}
+			current = section{start: pos, headings: headings}
+		}
+
+		pos = lineEnd + 1
+	}
+
+	current.end = len(text)
+	if current.end > current.start {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// parseHeading parses "## Title ##" into level 2 and "Title".
+func parseHeading(line string) (int, string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(line) && line[level] != ' ' && line[level] != '\t') {
+		return 0, "", false
+	}
+	title := strings.TrimSpace(line[level:])
+	// a closing sequence needs a space before it, "# C#" keeps its title
+	if closed := strings.TrimRight(title, "#"); closed == "" || strings.HasSuffix(closed, " ") {
+		title = strings.TrimSpace(closed)
+	}
+	return level, title, true
+}
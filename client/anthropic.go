@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opus-domini/fast-shot/constant/header"
+
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+	"github.com/andrejsstepanovs/go-litellm/sse"
+)
+
+const ANTHROPIC_VERSION = "2023-06-01"
+
+// Messages sends an Anthropic-native request to /v1/messages. Document blocks, citations, cache control
+// per block and thinking budgets pass through without the chat completion translation.
+func (l *Litellm) Messages(ctx context.Context, req *request.AnthropicMessages) (response.AnthropicResponse, error) {
+	if err := l.checkMessages(ctx, req); err != nil {
+		return response.AnthropicResponse{}, err
+	}
+	body := req.WithDefaults()
+	body.Stream = false
+
+	builder := l.client(cfg.CLIENT_LLM).POST("/v1/messages")
+	for key, value := range anthropicHeaders(req) {
+		builder = builder.Header().Set(header.Type(key), value)
+	}
+	res, err := sendJSON[response.AnthropicResponse](ctx, l, builder, body)
+	if err != nil {
+		return response.AnthropicResponse{}, fmt.Errorf("failed to get messages result: %w", err)
+	}
+	return res, nil
+}
+
+// MessagesStream streams a message and calls fn for every event, fn is optional.
+// It returns the message assembled from the events.
+func (l *Litellm) MessagesStream(ctx context.Context, req *request.AnthropicMessages, fn func(response.AnthropicEvent) error) (response.AnthropicResponse, error) {
+	if err := l.checkMessages(ctx, req); err != nil {
+		return response.AnthropicResponse{}, err
+	}
+	body := req.WithDefaults()
+	body.Stream = true
+
+	resp, err := l.postStream(ctx, "/v1/messages", body, anthropicHeaders(req))
+	if err != nil {
+		return response.AnthropicResponse{}, err
+	}
+	defer closeBody(resp)
+
+	var res response.AnthropicResponse
+	stopped := false
+	err = sse.Read(resp.Body, func(e sse.Event) error {
+		var event response.AnthropicEvent
+		if err := json.Unmarshal(e.Data, &event); err != nil {
+			return fmt.Errorf("failed to parse messages event: %w", err)
+		}
+		if event.Type == response.ANTHROPIC_EVENT_ERROR {
+			if event.Error == nil {
+				return errors.New("messages stream error")
+			}
+			return fmt.Errorf("messages stream error: %s: %s", event.Error.Type, event.Error.Message)
+		}
+		if err := res.Apply(event); err != nil {
+			return fmt.Errorf("failed to apply messages event: %w", err)
+		}
+		if fn != nil {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		if event.Type == response.ANTHROPIC_EVENT_MESSAGE_STOP {
+			stopped = true
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+	if !stopped {
+		return res, errors.New("messages stream ended before message_stop")
+	}
+	return res, nil
+}
+
+// checkMessages validates req and runs the ModerationGuard, like Completion does.
+func (l *Litellm) checkMessages(ctx context.Context, req *request.AnthropicMessages) error {
+	if req == nil {
+		return errors.New("messages request cannot be nil")
+	}
+	if req.Model == "" {
+		return errors.New("model cannot be empty")
+	}
+	if len(req.Messages) == 0 {
+		return errors.New("messages cannot be empty")
+	}
+	if err := req.ValidateToolChoice(); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return l.moderate(ctx, req.ModerationInputs())
+}
+
+func anthropicHeaders(req *request.AnthropicMessages) map[string]string {
+	headers := map[string]string{"anthropic-version": ANTHROPIC_VERSION}
+	if len(req.Betas) > 0 {
+		headers["anthropic-beta"] = strings.Join(req.Betas, ",")
+	}
+	return headers
+}
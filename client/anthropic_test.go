@@ -0,0 +1,163 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func messagesRequest() *request.AnthropicMessages {
+	return &request.AnthropicMessages{
+		Model: "claude-sonnet-4",
+		Messages: []request.AnthropicMessage{{
+			Role: request.ROLE_USER,
+			Content: []request.AnthropicContent{
+				request.AnthropicDocument(request.AnthropicSource{Type: request.ANTHROPIC_SOURCE_TEXT, MediaType: "text/plain", Data: "The sky is blue."}, "facts", true).
+					Cache(request.CacheControlEphemeral),
+				request.AnthropicText("What color is the sky?"),
+			},
+		}},
+		Betas: []string{"files-api-2025-04-14"},
+	}
+}
+
+func TestMessages(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "2023-06-01", r.Header.Get("anthropic-version"))
+		assert.Equal(t, "files-api-2025-04-14", r.Header.Get("anthropic-beta"))
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, float64(request.ANTHROPIC_MAX_TOKENS), body["max_tokens"])
+		assert.NotContains(t, body, "stream")
+		content := body["messages"].([]any)[0].(map[string]any)["content"].([]any)
+		assert.Equal(t, map[string]any{"enabled": true}, content[0].(map[string]any)["citations"])
+		assert.Equal(t, map[string]any{"type": "ephemeral"}, content[0].(map[string]any)["cache_control"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"msg-1","type":"message","role":"assistant","model":"claude-sonnet-4",
+			"content":[{"type":"text","text":"Blue.","citations":[{"type":"char_location","cited_text":"The sky is blue.","document_index":0,"document_title":"facts","start_char_index":0,"end_char_index":16}]}],
+			"stop_reason":"end_turn","usage":{"input_tokens":20,"output_tokens":2,"cache_creation_input_tokens":10}}`))
+	})
+
+	res, err := clientInstance.Messages(context.Background(), messagesRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "Blue.", res.Text())
+	assert.Equal(t, "The sky is blue.", res.Content[0].Citations[0].CitedText)
+	assert.Equal(t, 10, res.Usage.CacheCreationInputTokens)
+
+	_, err = clientInstance.Messages(context.Background(), &request.AnthropicMessages{Model: "claude-sonnet-4"})
+	assert.ErrorContains(t, err, "messages cannot be empty")
+}
+
+func TestMessagesStream(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, true, body["stream"])
+		assert.Equal(t, map[string]any{"type": "enabled", "budget_tokens": float64(8000)}, body["thinking"])
+		assert.Equal(t, float64(8000+request.ANTHROPIC_MAX_TOKENS), body["max_tokens"])
+
+		writeEvents(w,
+			`{"type":"message_start","message":{"id":"msg-1","type":"message","role":"assistant","model":"claude-sonnet-4","content":[],"usage":{"input_tokens":30,"output_tokens":1}}}`,
+			`{"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":""}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"need the tool"}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"signature_delta","signature":"sig-1"}}`,
+			`{"type":"content_block_stop","index":0}`,
+			`{"type":"ping"}`,
+			`{"type":"content_block_start","index":1,"content_block":{"type":"text","text":""}}`,
+			`{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"Checking"}}`,
+			`{"type":"content_block_stop","index":1}`,
+			`{"type":"content_block_start","index":2,"content_block":{"type":"tool_use","id":"toolu-1","name":"get_weather","input":{}}}`,
+			`{"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":"{\"city\":"}}`,
+			`{"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta","partial_json":"\"Riga\"}"}}`,
+			`{"type":"content_block_stop","index":2}`,
+			`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":42}}`,
+			`{"type":"message_stop"}`,
+		)
+	})
+
+	req := messagesRequest()
+	req.Thinking = request.ThinkingBudget(8000)
+	var text string
+	res, err := clientInstance.MessagesStream(context.Background(), req, func(event response.AnthropicEvent) error {
+		if event.Delta != nil {
+			text += event.Delta.Text
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Checking", text)
+	assert.Equal(t, "Checking", res.Text())
+	assert.Equal(t, response.ANTHROPIC_STOP_TOOL_USE, res.StopReason)
+	assert.Equal(t, 30, res.Usage.InputTokens)
+	assert.Equal(t, 42, res.Usage.OutputTokens)
+	assert.Equal(t, "sig-1", res.ThinkingBlocks()[0].Signature)
+	assert.JSONEq(t, `{"city":"Riga"}`, string(res.Content[2].Input))
+}
+
+func TestMessagesStream_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []string
+		err    string
+	}{
+		{
+			name:   "error event",
+			events: []string{`{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`},
+			err:    "messages stream error: overloaded_error: Overloaded",
+		},
+		{
+			name:   "truncated",
+			events: []string{`{"type":"message_start","message":{"id":"msg-1","content":[]}}`},
+			err:    "messages stream ended before message_stop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeEvents(w, tt.events...)
+			})
+			_, err := clientInstance.MessagesStream(context.Background(), messagesRequest(), nil)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestMessages_Guard(t *testing.T) {
+	calls := 0
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/moderations" {
+			_, _ = w.Write([]byte(moderationResponse))
+			return
+		}
+		calls++
+		_, _ = w.Write([]byte(`{"id":"msg-1","type":"message","content":[]}`))
+	})
+
+	req := messagesRequest()
+	req.ToolChoice = &request.AnthropicToolChoice{Type: "tool", Name: "get_weather"}
+	_, err := clientInstance.Messages(context.Background(), req)
+	assert.ErrorContains(t, err, `invalid request: tool_choice tool "get_weather" is not in request tools`)
+
+	clientInstance.ModerationGuard = &client.ModerationGuard{Model: models.ModelMeta{ModelId: "omni-moderation-latest"}}
+	_, err = clientInstance.Messages(context.Background(), messagesRequest())
+	var blocked *client.ModerationError
+	require.True(t, errors.As(err, &blocked))
+	_, err = clientInstance.MessagesStream(context.Background(), messagesRequest(), nil)
+	require.True(t, errors.As(err, &blocked))
+	assert.Zero(t, calls)
+}
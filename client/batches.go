@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/andrejsstepanovs/go-litellm/batch"
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// CreateBatch starts a batch job for an uploaded input file.
+func (l *Litellm) CreateBatch(ctx context.Context, req request.Batch) (response.Batch, error) {
+	if req.InputFileID == "" {
+		return response.Batch{}, fmt.Errorf("input file id cannot be empty")
+	}
+	return sendJSON[response.Batch](ctx, l, l.client(cfg.CLIENT_LLM).POST("/v1/batches"), req.WithDefaults())
+}
+
+func (l *Litellm) RetrieveBatch(ctx context.Context, batchID string) (response.Batch, error) {
+	if batchID == "" {
+		return response.Batch{}, fmt.Errorf("batch id cannot be empty")
+	}
+	return sendJSON[response.Batch](ctx, l, l.client(cfg.CLIENT_LLM).GET("/v1/batches/"+url.PathEscape(batchID)), nil)
+}
+
+func (l *Litellm) CancelBatch(ctx context.Context, batchID string) (response.Batch, error) {
+	if batchID == "" {
+		return response.Batch{}, fmt.Errorf("batch id cannot be empty")
+	}
+	return sendJSON[response.Batch](ctx, l, l.client(cfg.CLIENT_LLM).POST("/v1/batches/"+url.PathEscape(batchID)+"/cancel"), nil)
+}
+
+// ListBatches lists batches, newest first. after and limit are optional for paging.
+func (l *Litellm) ListBatches(ctx context.Context, after string, limit int) (response.BatchList, error) {
+	builder := l.client(cfg.CLIENT_LLM).GET("/v1/batches")
+	if after != "" {
+		builder = builder.Query().AddParam("after", after)
+	}
+	if limit > 0 {
+		builder = builder.Query().AddParam("limit", strconv.Itoa(limit))
+	}
+	return sendJSON[response.BatchList](ctx, l, builder, nil)
+}
+
+// SubmitBatch writes inputs as batch JSONL, uploads it and creates a chat completion batch.
+// Use batch.NewInputs to build inputs from requests.
+func (l *Litellm) SubmitBatch(ctx context.Context, inputs []batch.Input, metadata map[string]string) (response.Batch, error) {
+	if len(inputs) == 0 {
+		return response.Batch{}, fmt.Errorf("inputs cannot be empty")
+	}
+
+	// the input file is streamed, it is written while it is uploaded
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(batch.WriteInputs(writer, inputs))
+	}()
+	file, err := l.UploadFile(ctx, request.FILE_PURPOSE_BATCH, fmt.Sprintf("batch_%s.jsonl", uuid.New().String()), reader)
+	_ = reader.Close()
+	if err != nil {
+		return response.Batch{}, err
+	}
+
+	return l.CreateBatch(ctx, request.Batch{InputFileID: file.ID, Metadata: metadata})
+}
+
+// WaitBatch polls the batch every interval (default 30s) until it reached a final status or ctx is done.
+func (l *Litellm) WaitBatch(ctx context.Context, batchID string, interval time.Duration) (response.Batch, error) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		b, err := l.RetrieveBatch(ctx, batchID)
+		if err != nil || b.Done() {
+			return b, err
+		}
+		select {
+		case <-ctx.Done():
+			return b, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// BatchResults downloads the output and error files of a finished batch and maps
+// every line to its custom_id. Inputs that were never processed have no result.
+func (l *Litellm) BatchResults(ctx context.Context, b response.Batch) (map[string]batch.Result, error) {
+	results := make(map[string]batch.Result)
+	err := l.BatchOutputs(ctx, b, func(output batch.Output) error {
+		results[output.CustomID] = output.Result()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// BatchOutputs streams the output and error files of a finished batch into fn line by line.
+// Use it instead of BatchResults when the results do not fit into memory.
+func (l *Litellm) BatchOutputs(ctx context.Context, b response.Batch, fn func(batch.Output) error) error {
+	for _, fileID := range []string{b.OutputFileID, b.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+		if err := l.readBatchFile(ctx, fileID, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBatchFile decodes the file while it is downloaded.
+func (l *Litellm) readBatchFile(ctx context.Context, fileID string, fn func(batch.Output) error) error {
+	reader, writer := io.Pipe()
+	downloaded := make(chan error, 1)
+	go func() {
+		_, err := l.FileContent(ctx, fileID, writer)
+		writer.CloseWithError(err)
+		downloaded <- err
+	}()
+
+	err := batch.ReadOutputs(reader, fn)
+	// stops the download when fn or decoding failed early
+	_ = reader.Close()
+	if downloadErr := <-downloaded; downloadErr != nil && !errors.Is(downloadErr, io.ErrClosedPipe) {
+		return fmt.Errorf("failed to download batch file %s: %w", fileID, downloadErr)
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/batch"
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// batchServer is a minimal Files and Batch API that answers every input with its message.
+func batchServer(t *testing.T) *client.Litellm {
+	uploaded := make(map[string][]byte)
+	polls := 0
+
+	return newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/files":
+			assert.Equal(t, "batch", r.FormValue("purpose"))
+			file, header, err := r.FormFile("file")
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.True(t, strings.HasSuffix(header.Filename, ".jsonl"))
+			content, _ := io.ReadAll(file)
+			uploaded["file-in"] = content
+			_, _ = w.Write([]byte(`{"id":"file-in","object":"file","bytes":` + strconv.Itoa(len(content)) + `,"filename":"` + header.Filename + `","purpose":"batch"}`))
+		case "POST /v1/batches":
+			var body request.Batch
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, request.Batch{InputFileID: "file-in", Endpoint: "/v1/chat/completions", CompletionWindow: "24h", Metadata: map[string]string{"job": "nightly"}}, body)
+			_, _ = w.Write([]byte(`{"id":"batch-1","status":"validating","input_file_id":"file-in"}`))
+		case "GET /v1/batches/batch-1":
+			polls++
+			if polls < 2 {
+				_, _ = w.Write([]byte(`{"id":"batch-1","status":"in_progress"}`))
+				return
+			}
+			var output bytes.Buffer
+			err := batch.ReadInputs(bytes.NewReader(uploaded["file-in"]), func(input batch.Input) error {
+				res := response.Response{ID: "resp-" + input.CustomID}
+				res.SetText("label for " + input.Body.Messages[0].Contents.String())
+				line, err := batch.NewOutput(input.CustomID, res, nil)
+				if err != nil {
+					return err
+				}
+				return batch.WriteOutput(&output, line)
+			})
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			uploaded["file-out"] = output.Bytes()
+			_, _ = w.Write([]byte(`{"id":"batch-1","status":"completed","output_file_id":"file-out","request_counts":{"total":2,"completed":2,"failed":0}}`))
+		case "GET /v1/files/file-out/content":
+			w.Header().Set("Content-Type", "application/jsonl")
+			_, _ = w.Write(uploaded["file-out"])
+		case "GET /v1/files/missing/content":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"file not found"}}`))
+		case "GET /v1/files":
+			assert.Equal(t, "batch", r.URL.Query().Get("purpose"))
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"file-in","purpose":"batch"}],"has_more":false}`))
+		case "GET /v1/files/file-in":
+			_, _ = w.Write([]byte(`{"id":"file-in","bytes":10,"purpose":"batch"}`))
+		case "DELETE /v1/files/file-in":
+			_, _ = w.Write([]byte(`{"id":"file-in","object":"file","deleted":true}`))
+		case "POST /v1/batches/batch-1/cancel":
+			_, _ = w.Write([]byte(`{"id":"batch-1","status":"cancelling"}`))
+		case "GET /v1/batches":
+			assert.Equal(t, "batch-0", r.URL.Query().Get("after"))
+			assert.Equal(t, "5", r.URL.Query().Get("limit"))
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"batch-1","status":"completed"}],"last_id":"batch-1","has_more":true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestBatch_SubmitWaitResults(t *testing.T) {
+	ctx := context.Background()
+	clientInstance := batchServer(t)
+	model := models.ModelMeta{ModelId: "gpt-4o-mini"}
+
+	requests := make([]*request.Request, 0)
+	for _, text := range []string{"first row", "second row"} {
+		req := request.NewRequest(model)
+		req.SetMessages(request.Messages{request.UserMessageSimple(text)})
+		requests = append(requests, req)
+	}
+
+	submitted, err := clientInstance.SubmitBatch(ctx, batch.NewInputs(requests), map[string]string{"job": "nightly"})
+	require.NoError(t, err)
+	assert.Equal(t, "batch-1", submitted.ID)
+	assert.False(t, submitted.Done())
+
+	done, err := clientInstance.WaitBatch(ctx, submitted.ID, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, response.BATCH_STATUS_COMPLETED, done.Status)
+	assert.Equal(t, 2, done.RequestCounts.Completed)
+
+	results, err := clientInstance.BatchResults(ctx, done)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	second := results["request-1"]
+	require.NoError(t, second.Err)
+	assert.Equal(t, "label for second row", second.Response.String())
+	assert.Equal(t, "resp-request-1", second.Response.ID)
+
+	var ids []string
+	err = clientInstance.BatchOutputs(ctx, done, func(output batch.Output) error {
+		ids = append(ids, output.CustomID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"request-0", "request-1"}, ids)
+
+	stop := errors.New("stop")
+	lines := 0
+	err = clientInstance.BatchOutputs(ctx, done, func(batch.Output) error {
+		lines++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, lines)
+
+	done.OutputFileID = "missing"
+	_, err = clientInstance.BatchResults(ctx, done)
+	assert.ErrorContains(t, err, "failed to download batch file missing")
+}
+
+func TestFilesAndBatches(t *testing.T) {
+	ctx := context.Background()
+	clientInstance := batchServer(t)
+
+	list, err := clientInstance.ListFiles(ctx, request.FILE_PURPOSE_BATCH)
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+
+	file, err := clientInstance.RetrieveFile(ctx, "file-in")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), file.Bytes)
+
+	deleted, err := clientInstance.DeleteFile(ctx, "file-in")
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+
+	_, err = clientInstance.FileContent(ctx, "missing", io.Discard)
+	assert.ErrorContains(t, err, "file not found")
+
+	cancelled, err := clientInstance.CancelBatch(ctx, "batch-1")
+	require.NoError(t, err)
+	assert.Equal(t, response.BATCH_STATUS_CANCELLING, cancelled.Status)
+
+	batches, err := clientInstance.ListBatches(ctx, "batch-0", 5)
+	require.NoError(t, err)
+	assert.True(t, batches.HasMore)
+	assert.Equal(t, "batch-1", batches.LastID)
+
+	_, err = clientInstance.CreateBatch(ctx, request.Batch{})
+	assert.ErrorContains(t, err, "input file id cannot be empty")
+	_, err = clientInstance.SubmitBatch(ctx, nil, nil)
+	assert.ErrorContains(t, err, "inputs cannot be empty")
+	_, err = clientInstance.UploadFile(ctx, "", "a.jsonl", strings.NewReader("x"))
+	assert.ErrorContains(t, err, "purpose cannot be empty")
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,7 +11,6 @@ import (
 	"strings"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/google/uuid"
 	fastshot "github.com/opus-domini/fast-shot"
 	"github.com/opus-domini/fast-shot/constant/header"
 	"github.com/opus-domini/fast-shot/constant/mime"
@@ -68,6 +66,8 @@ func validateExtraHeaders(headers map[string]string) error {
 type Litellm struct {
 	Config     Config
 	Connection cfg.Connection
+	// ModerationGuard is optional, see ModerationGuard.
+	ModerationGuard *ModerationGuard
 }
 
 func New(config Config, connection cfg.Connection) (*Litellm, error) {
@@ -304,6 +304,12 @@ func (l *Litellm) Completion(ctx context.Context, req *request.Request) (respons
 	if len(req.Messages) == 0 {
 		return response.Response{}, fmt.Errorf("messages cannot be empty")
 	}
+	if err := req.ValidateToolChoice(); err != nil {
+		return response.Response{}, fmt.Errorf("invalid request: %w", err)
+	}
+	if err := l.moderate(ctx, req.ModerationInputs()); err != nil {
+		return response.Response{}, err
+	}
 
 	target := l.Connection.Targets.Get(cfg.CLIENT_LLM)
 	resp, err := l.client(cfg.CLIENT_LLM).
@@ -341,14 +347,58 @@ func (l *Litellm) Completion(ctx context.Context, req *request.Request) (respons
 }
 
 func (l *Litellm) SpeechToText(ctx context.Context, model models.ModelMeta, audioFile string, extraBody map[string]any) (audio.AudioResponse, error) {
-	url := fmt.Sprintf("%s/audio/transcriptions", l.Connection.URL.String())
-	resp, err := audio.TranscribeAudio(url, l.Config.APIKey, audioFile, string(model.ModelId), extraBody, l.Config.ExtraHeaders)
+	return l.transcribeFile(ctx, "audio/transcriptions", model, audioFile, request.Transcription{ExtraBody: extraBody})
+}
+
+// Transcribe converts speech to text in the spoken language using typed options.
+func (l *Litellm) Transcribe(ctx context.Context, model models.ModelMeta, audioFile string, options request.Transcription) (audio.AudioResponse, error) {
+	return l.transcribeFile(ctx, "audio/transcriptions", model, audioFile, options)
+}
+
+// Translate converts speech in any supported language to English text.
+func (l *Litellm) Translate(ctx context.Context, model models.ModelMeta, audioFile string, options request.Transcription) (audio.AudioResponse, error) {
+	return l.transcribeFile(ctx, "audio/translations", model, audioFile, options)
+}
+
+// TranscribeReader streams audio from upload.Reader without temp files or buffering.
+func (l *Litellm) TranscribeReader(ctx context.Context, model models.ModelMeta, upload audio.Upload, options request.Transcription) (audio.AudioResponse, error) {
+	return l.transcribe(ctx, "audio/transcriptions", model, upload, options)
+}
+
+// TranslateReader streams audio from upload.Reader and translates it to English text.
+func (l *Litellm) TranslateReader(ctx context.Context, model models.ModelMeta, upload audio.Upload, options request.Transcription) (audio.AudioResponse, error) {
+	return l.transcribe(ctx, "audio/translations", model, upload, options)
+}
+
+func (l *Litellm) transcribeFile(ctx context.Context, endpoint string, model models.ModelMeta, audioFile string, options request.Transcription) (audio.AudioResponse, error) {
+	upload, file, err := audio.OpenUpload(audioFile)
+	if err != nil {
+		return audio.AudioResponse{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Warning: failed to close audio file: %v", err)
+		}
+	}()
+
+	return l.transcribe(ctx, endpoint, model, upload, options)
+}
+
+func (l *Litellm) transcribe(ctx context.Context, endpoint string, model models.ModelMeta, upload audio.Upload, options request.Transcription) (audio.AudioResponse, error) {
+	err := options.Validate()
+	if err != nil {
+		return audio.AudioResponse{}, err
+	}
+
+	fields := options.Fields()
+	url := fmt.Sprintf("%s/%s", l.Connection.URL.String(), endpoint)
+	resp, err := audio.TranscribeUpload(ctx, url, l.Config.APIKey, upload, string(model.ModelId), fields, l.Config.ExtraHeaders)
 	if err != nil {
 		return audio.AudioResponse{}, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
-			panic(err)
+			log.Printf("Warning: failed to close response body: %v", err)
 		}
 	}()
 
@@ -360,8 +410,9 @@ func (l *Litellm) SpeechToText(ctx context.Context, model models.ModelMeta, audi
 		return audio.AudioResponse{}, errors.New(string(msg))
 	}
 
-	var audioResponse audio.AudioResponse
-	err = json.Unmarshal(msg, &audioResponse)
+	// response_format may also arrive through ExtraBody
+	responseFormat, _ := fields["response_format"].(string)
+	audioResponse, err := audio.ParseTranscription(responseFormat, msg)
 	if err != nil {
 		return audio.AudioResponse{}, fmt.Errorf("failed to parse speech-to-text response: %w", err)
 	}
@@ -369,33 +420,26 @@ func (l *Litellm) SpeechToText(ctx context.Context, model models.ModelMeta, audi
 	return audioResponse, nil
 }
 
-func (l *Litellm) TextToSpeech(ctx context.Context, speechRequest request.Speech) (response.Speech, error) {
-	url := fmt.Sprintf("%s/audio/speech", l.Connection.URL.String())
-	resp, err := audio.Speech(url, l.Config.APIKey, speechRequest, l.Config.ExtraHeaders)
-	if err != nil {
-		return response.Speech{}, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("Warning: failed to close response body: %v", err)
-		}
-	}()
+// TextToSpeech generates speech and stores it in a file.
+// By default the file is a uniquely named file in os.TempDir(), see audio.SpeechFileOption.
+func (l *Litellm) TextToSpeech(ctx context.Context, speechRequest request.Speech, options ...audio.SpeechFileOption) (response.Speech, error) {
+	return writeSpeechFile(speechRequest, options, func(w io.Writer) error {
+		_, err := l.TextToSpeechStream(ctx, speechRequest, w)
+		return err
+	})
+}
 
-	if resp.StatusCode != 200 {
-		msg, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return response.Speech{}, fmt.Errorf("failed to read error response (status %d): %w", resp.StatusCode, err)
-		}
-		return response.Speech{}, fmt.Errorf("speech API returned status %d: %s", resp.StatusCode, string(msg))
-	}
+// writeSpeechFile creates the output file and removes it again when write fails.
+func writeSpeechFile(speechRequest request.Speech, options []audio.SpeechFileOption, write func(io.Writer) error) (response.Speech, error) {
+	extension := speechExtension(speechRequest)
+	output := audio.NewSpeechFile(options...)
+	dir := output.Directory
+	fileName := output.Filename(extension)
 
-	extension := speechRequest.ResponseFormat
-	if extension == "" {
-		extension = "mp3"
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return response.Speech{}, fmt.Errorf("failed to create audio directory %q: %w", dir, err)
 	}
-	random := uuid.Must(uuid.NewRandom()).String()
-	fileName := fmt.Sprintf("speech_%s.%s", random, extension)
-	dir := os.TempDir()
 
 	fullFilePath := filepath.Join(dir, fileName)
 	audioFile, err := os.Create(fullFilePath)
@@ -403,10 +447,11 @@ func (l *Litellm) TextToSpeech(ctx context.Context, speechRequest request.Speech
 		return response.Speech{}, fmt.Errorf("failed to create audio file %q: %w", fullFilePath, err)
 	}
 
-	_, err = io.Copy(audioFile, resp.Body)
+	err = write(audioFile)
 	if err != nil {
+		_ = audioFile.Close()
 		_ = os.Remove(fullFilePath)
-		return response.Speech{}, fmt.Errorf("failed to write audio data to file %q: %w", fullFilePath, err)
+		return response.Speech{}, err
 	}
 
 	err = audioFile.Close()
@@ -423,6 +468,46 @@ func (l *Litellm) TextToSpeech(ctx context.Context, speechRequest request.Speech
 	}, nil
 }
 
+func speechExtension(speechRequest request.Speech) string {
+	if speechRequest.ResponseFormat == "" {
+		return "mp3"
+	}
+	return speechRequest.ResponseFormat
+}
+
+// TextToSpeechStream writes generated audio to w as it arrives and returns the number of bytes written.
+// With StreamFormat "sse" the base64 audio deltas of the event stream are decoded.
+func (l *Litellm) TextToSpeechStream(ctx context.Context, speechRequest request.Speech, w io.Writer) (int64, error) {
+	url := fmt.Sprintf("%s/audio/speech", l.Connection.URL.String())
+	resp, err := audio.SpeechWithContext(ctx, url, l.Config.APIKey, speechRequest, l.Config.ExtraHeaders)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Warning: failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != 200 {
+		msg, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read error response (status %d): %w", resp.StatusCode, err)
+		}
+		return 0, fmt.Errorf("speech API returned status %d: %s", resp.StatusCode, string(msg))
+	}
+
+	if speechRequest.StreamFormat == audio.SPEECH_STREAM_FORMAT_SSE || strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return audio.DecodeSpeechEvents(resp.Body, w)
+	}
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("failed to write audio data: %w", err)
+	}
+	return n, nil
+}
+
 // Embeddings retrieves text embeddings from the LiteLLM service.
 func (l *Litellm) Embeddings(ctx context.Context, model models.ModelMeta, inputText string) (response.EmbeddingResponse, error) {
 	if inputText == "" {
@@ -434,6 +519,23 @@ func (l *Litellm) Embeddings(ctx context.Context, model models.ModelMeta, inputT
 		Input: inputText,
 	}
 
+	return l.embeddings(ctx, req)
+}
+
+// EmbeddingsRequest retrieves embeddings for a request with optional parameters such as dimensions or encoding_format.
+func (l *Litellm) EmbeddingsRequest(ctx context.Context, req request.EmbeddingRequest) (response.EmbeddingResponse, error) {
+	if req.Input == "" && len(req.Inputs) == 0 {
+		return response.EmbeddingResponse{}, fmt.Errorf("input cannot be empty")
+	}
+	err := req.EmbeddingParams.Validate()
+	if err != nil {
+		return response.EmbeddingResponse{}, fmt.Errorf("invalid request: %w", err)
+	}
+
+	return l.embeddings(ctx, req)
+}
+
+func (l *Litellm) embeddings(ctx context.Context, req request.EmbeddingRequest) (response.EmbeddingResponse, error) {
 	target := l.Connection.Targets.Get(cfg.CLIENT_LLM)
 	resp, err := l.client(cfg.CLIENT_LLM).
 		POST("/v1/embeddings").
@@ -490,3 +592,12 @@ func (l *Litellm) TokenCounter(ctx context.Context, req *request.TokenCounterReq
 
 	return &res, nil
 }
+
+// checkMode fails when the model metadata says the model is meant for a different endpoint.
+// Models without a mode (e.g. unknown to LiteLLM) are allowed through.
+func checkMode(model models.ModelMeta, mode string) error {
+	if model.Mode == "" || model.Mode == mode {
+		return nil
+	}
+	return fmt.Errorf("model %q has mode %q, expected %q", model.ModelId, model.Mode, mode)
+}
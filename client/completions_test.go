@@ -155,6 +155,16 @@ func TestCompletions(t *testing.T) {
 		_, err = clientInstance.Completion(context.Background(), req)
 		assert.Error(t, err)
 	})
+
+	t.Run("fail unknown tool choice function", func(t *testing.T) {
+		req := request.NewCompletionRequest(models.ModelMeta{ModelId: "test"}, request.Messages{
+			request.UserMessageSimple("test"),
+		}, request.LLMCallTools{{Type: "function", Function: &request.LLMCallToolFunction{Name: "current_time"}}}, nil, 0.7)
+		req.SetToolChoice(request.ToolChoiceFunction("curent_time"))
+
+		_, err = clientInstance.Completion(context.Background(), req)
+		assert.ErrorContains(t, err, `tool_choice function "curent_time" is not in request tools`)
+	})
 }
 
 func TestCompletion_ErrorScenarios(t *testing.T) {
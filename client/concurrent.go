@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// runConcurrent calls fn for 0..n-1 with at most concurrency calls at a time, <= 0 uses 4.
+// The first error cancels the ctx passed to fn and is returned, calls that did not start yet are skipped.
+// When ctx is cancelled by the caller its error is returned.
+func runConcurrent(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	semaphore := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-ctx.Done():
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err := fn(ctx, i); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// EmbeddingsBatch embeds many inputs with as few requests as the limits allow.
+// Inputs are split by count and estimated tokens and the requests run concurrently.
+// A failed request or empty input does not fail the whole call, it is reported per input
+// in EmbeddingBatchResponse.Errors, see EmbeddingBatchResponse.Err.
+func (l *Litellm) EmbeddingsBatch(ctx context.Context, model models.ModelMeta, inputs []string, options request.EmbeddingBatchOptions) (response.EmbeddingBatchResponse, error) {
+	if len(inputs) == 0 {
+		return response.EmbeddingBatchResponse{}, fmt.Errorf("inputs cannot be empty")
+	}
+	err := options.Params.Validate()
+	if err != nil {
+		return response.EmbeddingBatchResponse{}, fmt.Errorf("invalid request: %w", err)
+	}
+
+	res := response.EmbeddingBatchResponse{
+		Model:  string(model.ModelId),
+		Data:   make([]response.EmbeddingData, len(inputs)),
+		Errors: make([]error, len(inputs)),
+	}
+
+	// empty inputs are rejected by providers and would fail the whole request
+	indexes := make([]int, 0, len(inputs))
+	texts := make([]string, 0, len(inputs))
+	for i, input := range inputs {
+		res.Data[i] = response.EmbeddingData{Object: "embedding", Index: i}
+		if input == "" {
+			res.Errors[i] = fmt.Errorf("input %d cannot be empty", i)
+			continue
+		}
+		indexes = append(indexes, i)
+		texts = append(texts, input)
+	}
+
+	batches := options.Batches(texts)
+	fail := func(batch [2]int, err error) {
+		for _, i := range indexes[batch[0]:batch[1]] {
+			res.Errors[i] = err
+		}
+	}
+
+	var mu sync.Mutex
+	started := make([]bool, len(batches))
+	_ = runConcurrent(ctx, len(batches), options.Concurrency, func(ctx context.Context, b int) error {
+		batch := batches[b]
+		started[b] = true
+
+		req := request.EmbeddingRequest{
+			Model:           string(model.ModelId),
+			Inputs:          texts[batch[0]:batch[1]],
+			EmbeddingParams: options.Params,
+		}
+		batchRes, err := l.embeddings(ctx, req)
+		if err != nil {
+			// failures are reported per input, the other batches keep running
+			fail(batch, fmt.Errorf("embedding batch %d-%d failed: %w", indexes[batch[0]], indexes[batch[1]-1], err))
+			return nil
+		}
+
+		found := make([]bool, batch[1]-batch[0])
+		for _, data := range batchRes.Data {
+			if data.Index < 0 || data.Index >= len(found) {
+				continue
+			}
+			found[data.Index] = true
+			data.Index = indexes[batch[0]+data.Index]
+			res.Data[data.Index] = data
+		}
+		for j, ok := range found {
+			if !ok {
+				res.Errors[indexes[batch[0]+j]] = fmt.Errorf("embedding missing in response")
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		res.Usage.PromptTokens += batchRes.Usage.PromptTokens
+		res.Usage.TotalTokens += batchRes.Usage.TotalTokens
+		if batchRes.Model != "" {
+			res.Model = batchRes.Model
+		}
+		return nil
+	})
+	for b, ok := range started {
+		if !ok {
+			fail(batches[b], ctx.Err())
+		}
+	}
+
+	return res, nil
+}
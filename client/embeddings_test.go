@@ -2,15 +2,20 @@ package client_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/andrejsstepanovs/go-litellm/client"
 	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
 	"github.com/andrejsstepanovs/go-litellm/response"
 )
 
@@ -129,3 +134,114 @@ func TestEmbeddings_ErrorScenarios(t *testing.T) {
 		})
 	}
 }
+
+func TestEmbeddingsBatch(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.LessOrEqual(t, len(req.Input), 2)
+
+		if slices.Contains(req.Input, "bad") {
+			http.Error(w, `{"error":{"message":"rejected"}}`, http.StatusBadRequest)
+			return
+		}
+
+		res := response.EmbeddingResponse{Object: "list", Model: req.Model, Usage: response.EmbeddingUsage{PromptTokens: len(req.Input), TotalTokens: len(req.Input)}}
+		// reversed order, the client must sort by index
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			if req.Input[i] == "dropped" {
+				continue
+			}
+			res.Data = append(res.Data, response.EmbeddingData{Object: "embedding", Index: i, Embedding: response.Embedding{float64(len(req.Input[i]))}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer server.Close()
+
+	testUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	conn := getConn()
+	conn.URL = *testUrl
+	clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+	inputs := []string{"a", "bb", "", "ccc", "bad", "dddd", "dropped"}
+	res, err := clientInstance.EmbeddingsBatch(context.Background(), models.ModelMeta{ModelId: "test-embedding-model"}, inputs,
+		request.EmbeddingBatchOptions{MaxInputs: 2, Concurrency: 2})
+	require.NoError(t, err)
+
+	// [a bb] [ccc bad] [dddd dropped], the empty input is never sent
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, "test-embedding-model", res.Model)
+	assert.Equal(t, response.EmbeddingUsage{PromptTokens: 4, TotalTokens: 4}, res.Usage)
+
+	require.Len(t, res.Data, len(inputs))
+	require.Len(t, res.Errors, len(inputs))
+	for i, data := range res.Data {
+		assert.Equal(t, i, data.Index)
+	}
+	assert.Equal(t, response.Embedding{1}, res.Data[0].Embedding)
+	assert.Equal(t, response.Embedding{2}, res.Data[1].Embedding)
+	assert.Equal(t, response.Embedding{4}, res.Data[5].Embedding)
+	assert.NoError(t, res.Errors[0])
+	assert.ErrorContains(t, res.Errors[2], "input 2 cannot be empty")
+	assert.ErrorContains(t, res.Errors[3], "embedding batch 3-4 failed")
+	assert.ErrorContains(t, res.Errors[4], "rejected")
+	assert.Nil(t, res.Data[4].Embedding)
+	assert.ErrorContains(t, res.Errors[6], "embedding missing in response")
+	assert.ErrorContains(t, res.Err(), "4 of 7 embeddings failed")
+
+	_, err = clientInstance.EmbeddingsBatch(context.Background(), models.ModelMeta{ModelId: "test-embedding-model"}, nil, request.EmbeddingBatchOptions{})
+	assert.ErrorContains(t, err, "inputs cannot be empty")
+}
+
+func TestEmbeddingsRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, float64(2), req["dimensions"])
+		assert.Equal(t, "base64", req["encoding_format"])
+		assert.Equal(t, "search_document", req["input_type"])
+
+		w.Header().Set("Content-Type", "application/json")
+		// 0.5 and -1 as little endian float32
+		_, err := w.Write([]byte(`{"object":"list","data":[{"object":"embedding","index":0,"embedding":"AAAAPwAAgL8="}],"model":"test-embedding-model"}`))
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	testUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	conn := getConn()
+	conn.URL = *testUrl
+	clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+	params := request.EmbeddingParams{Dimensions: 2, EncodingFormat: request.EMBEDDING_ENCODING_BASE64, InputType: "search_document"}
+	res, err := clientInstance.EmbeddingsRequest(context.Background(), request.EmbeddingRequest{
+		Model:           "test-embedding-model",
+		Input:           "document",
+		EmbeddingParams: params,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 1)
+	assert.Equal(t, []float32{0.5, -1}, res.Data[0].Embedding.Float32())
+
+	batch, err := clientInstance.EmbeddingsBatch(context.Background(), models.ModelMeta{ModelId: "test-embedding-model"}, []string{"document"},
+		request.EmbeddingBatchOptions{Params: params})
+	require.NoError(t, err)
+	require.NoError(t, batch.Err())
+	assert.Equal(t, response.Embedding{0.5, -1}, batch.Data[0].Embedding)
+
+	_, err = clientInstance.EmbeddingsRequest(context.Background(), request.EmbeddingRequest{Model: "test-embedding-model"})
+	assert.ErrorContains(t, err, "input cannot be empty")
+
+	_, err = clientInstance.EmbeddingsRequest(context.Background(), request.EmbeddingRequest{
+		Model: "test-embedding-model", Input: "document", EmbeddingParams: request.EmbeddingParams{EncodingFormat: "int8"},
+	})
+	assert.ErrorContains(t, err, "invalid request")
+}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+
+	fastshot "github.com/opus-domini/fast-shot"
+	"github.com/opus-domini/fast-shot/constant/mime"
+
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/files"
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// UploadFile streams r to /v1/files, e.g. a batch input with purpose request.FILE_PURPOSE_BATCH.
+func (l *Litellm) UploadFile(ctx context.Context, purpose, filename string, r io.Reader) (response.File, error) {
+	fileURL := fmt.Sprintf("%s/v1/files", l.Connection.URL.String())
+	resp, err := files.Upload(ctx, fileURL, l.Config.APIKey, purpose, filename, r, l.Config.ExtraHeaders)
+	if err != nil {
+		return response.File{}, fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Warning: failed to close response body: %v", err)
+		}
+	}()
+
+	msg, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return response.File{}, fmt.Errorf("failed to read file response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return response.File{}, errors.New(string(msg))
+	}
+
+	var res response.File
+	err = json.Unmarshal(msg, &res)
+	if err != nil {
+		return response.File{}, fmt.Errorf("failed to parse file response: %w", err)
+	}
+	return res, nil
+}
+
+// ListFiles lists uploaded files, purpose is optional.
+func (l *Litellm) ListFiles(ctx context.Context, purpose string) (response.FileList, error) {
+	builder := l.client(cfg.CLIENT_LLM).GET("/v1/files")
+	if purpose != "" {
+		builder = builder.Query().AddParam("purpose", purpose)
+	}
+	return sendJSON[response.FileList](ctx, l, builder, nil)
+}
+
+func (l *Litellm) RetrieveFile(ctx context.Context, fileID string) (response.File, error) {
+	if fileID == "" {
+		return response.File{}, fmt.Errorf("file id cannot be empty")
+	}
+	return sendJSON[response.File](ctx, l, l.client(cfg.CLIENT_LLM).GET("/v1/files/"+url.PathEscape(fileID)), nil)
+}
+
+func (l *Litellm) DeleteFile(ctx context.Context, fileID string) (response.FileDeleted, error) {
+	if fileID == "" {
+		return response.FileDeleted{}, fmt.Errorf("file id cannot be empty")
+	}
+	return sendJSON[response.FileDeleted](ctx, l, l.client(cfg.CLIENT_LLM).DELETE("/v1/files/"+url.PathEscape(fileID)), nil)
+}
+
+// FileContent streams the content of a file into w, e.g. a batch output file.
+func (l *Litellm) FileContent(ctx context.Context, fileID string, w io.Writer) (int64, error) {
+	if fileID == "" {
+		return 0, fmt.Errorf("file id cannot be empty")
+	}
+
+	target := l.Connection.Targets.Get(cfg.CLIENT_LLM)
+	resp, err := l.client(cfg.CLIENT_LLM).
+		GET("/v1/files/"+url.PathEscape(fileID)+"/content").
+		Context().Set(ctx).
+		Retry().SetExponentialBackoff(
+		target.RetryInterval,
+		target.RetryMaxAttempts,
+		target.RetryBackoffRate).
+		Send()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body().Close()
+
+	if resp.Status().IsError() {
+		msg, err := resp.Body().AsString()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read error response: %w", err)
+		}
+		return 0, errors.New(msg)
+	}
+
+	n, err := io.Copy(w, resp.Body().Raw())
+	if err != nil {
+		return n, fmt.Errorf("failed to write file content: %w", err)
+	}
+	return n, nil
+}
+
+// sendJSON sends builder with retries and parses the JSON response. body is optional.
+func sendJSON[T any](ctx context.Context, l *Litellm, builder *fastshot.RequestBuilder, body any) (T, error) {
+	var res T
+	target := l.Connection.Targets.Get(cfg.CLIENT_LLM)
+	builder = builder.
+		Context().Set(ctx).
+		Header().AddAccept(mime.JSON).
+		Retry().SetExponentialBackoff(
+		target.RetryInterval,
+		target.RetryMaxAttempts,
+		target.RetryBackoffRate)
+	if body != nil {
+		builder = builder.Body().AsJSON(body)
+	}
+
+	resp, err := builder.Send()
+	if err != nil {
+		return res, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body().Close()
+
+	err = httpresp.ParseHTTPResponse(*resp, &res)
+	return res, err
+}
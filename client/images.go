@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/opus-domini/fast-shot/constant/mime"
+
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
+	"github.com/andrejsstepanovs/go-litellm/images"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// ImageGeneration creates images from a prompt. Use ImageResponse.SaveAll to store b64_json results.
+func (l *Litellm) ImageGeneration(ctx context.Context, model models.ModelMeta, req request.ImageGeneration) (response.ImageResponse, error) {
+	if err := checkMode(model, models.MODE_IMAGE_GENERATION); err != nil {
+		return response.ImageResponse{}, err
+	}
+	if req.Prompt == "" {
+		return response.ImageResponse{}, fmt.Errorf("prompt cannot be empty")
+	}
+	req.Model = model.ModelId
+
+	target := l.Connection.Targets.Get(cfg.CLIENT_LLM)
+	resp, err := l.client(cfg.CLIENT_LLM).
+		POST("/images/generations").
+		Context().Set(ctx).
+		Header().AddAccept(mime.JSON).
+		Retry().SetExponentialBackoff(
+		target.RetryInterval,
+		target.RetryMaxAttempts,
+		target.RetryBackoffRate).
+		Body().AsJSON(req).
+		Send()
+
+	if err != nil {
+		return response.ImageResponse{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body().Close()
+
+	var res response.ImageResponse
+	err = httpresp.ParseHTTPResponse(*resp, &res)
+	if err != nil {
+		return response.ImageResponse{}, fmt.Errorf("failed to parse image generation response: %w", err)
+	}
+
+	return res, nil
+}
+
+// ImageEdit edits images (with an optional mask) using a prompt.
+func (l *Litellm) ImageEdit(ctx context.Context, model models.ModelMeta, req request.ImageEdit) (response.ImageResponse, error) {
+	if err := checkMode(model, models.MODE_IMAGE_GENERATION); err != nil {
+		return response.ImageResponse{}, err
+	}
+	if req.Prompt == "" {
+		return response.ImageResponse{}, fmt.Errorf("prompt cannot be empty")
+	}
+	req.Model = model.ModelId
+
+	url := fmt.Sprintf("%s/images/edits", l.Connection.URL.String())
+	resp, err := images.Edit(ctx, url, l.Config.APIKey, req, l.Config.ExtraHeaders)
+	if err != nil {
+		return response.ImageResponse{}, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return parseImageResponse(resp)
+}
+
+// ImageVariation creates variations of an image.
+func (l *Litellm) ImageVariation(ctx context.Context, model models.ModelMeta, req request.ImageVariation) (response.ImageResponse, error) {
+	if err := checkMode(model, models.MODE_IMAGE_GENERATION); err != nil {
+		return response.ImageResponse{}, err
+	}
+	req.Model = model.ModelId
+
+	url := fmt.Sprintf("%s/images/variations", l.Connection.URL.String())
+	resp, err := images.Variation(ctx, url, l.Config.APIKey, req, l.Config.ExtraHeaders)
+	if err != nil {
+		return response.ImageResponse{}, fmt.Errorf("failed to send request: %w", err)
+	}
+
+	return parseImageResponse(resp)
+}
+
+func parseImageResponse(resp *http.Response) (response.ImageResponse, error) {
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("Warning: failed to close response body: %v", err)
+		}
+	}()
+
+	msg, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return response.ImageResponse{}, fmt.Errorf("failed to read image response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return response.ImageResponse{}, errors.New(string(msg))
+	}
+
+	var res response.ImageResponse
+	err = json.Unmarshal(msg, &res)
+	if err != nil {
+		return response.ImageResponse{}, fmt.Errorf("failed to parse image response: %w", err)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,129 @@
+package client_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+// 1x1 transparent png
+const testPNGBase64 = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="
+
+func TestImageGeneration(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/images/generations", r.URL.Path)
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "gpt-image-1", body["model"])
+		assert.Equal(t, "a red square", body["prompt"])
+		assert.Equal(t, "1024x1024", body["size"])
+		assert.Equal(t, "b64_json", body["response_format"])
+		assert.Equal(t, float64(2), body["n"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"created":1700000000,"data":[{"b64_json":"` + testPNGBase64 + `"},{"b64_json":"` + testPNGBase64 + `","revised_prompt":"red"}]}`))
+	})
+
+	model := models.ModelMeta{ModelId: "gpt-image-1", Mode: models.MODE_IMAGE_GENERATION}
+	res, err := clientInstance.ImageGeneration(context.Background(), model, request.ImageGeneration{
+		Prompt:         "a red square",
+		Size:           "1024x1024",
+		N:              2,
+		ResponseFormat: request.IMAGE_RESPONSE_FORMAT_B64,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 2)
+	assert.Equal(t, "red", res.Data[1].RevisedPrompt)
+
+	dir := t.TempDir()
+	saved, err := res.SaveAll(dir)
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, "png", saved[0].Extension)
+	assert.Equal(t, dir, saved[0].Directory)
+	assert.FileExists(t, saved[0].Full)
+}
+
+func TestImageGeneration_Validation(t *testing.T) {
+	clientInstance := client.Litellm{Config: getConfig(), Connection: getConn()}
+
+	_, err := clientInstance.ImageGeneration(context.Background(), models.ModelMeta{ModelId: "gpt-4o", Mode: models.MODE_CHAT}, request.ImageGeneration{Prompt: "x"})
+	assert.ErrorContains(t, err, `model "gpt-4o" has mode "chat", expected "image_generation"`)
+
+	_, err = clientInstance.ImageGeneration(context.Background(), models.ModelMeta{ModelId: "dall-e-3"}, request.ImageGeneration{})
+	assert.ErrorContains(t, err, "prompt cannot be empty")
+}
+
+func TestImageEdit(t *testing.T) {
+	dir := t.TempDir()
+	png, err := base64.StdEncoding.DecodeString(testPNGBase64)
+	require.NoError(t, err)
+	imagePath := filepath.Join(dir, "product.png")
+	maskPath := filepath.Join(dir, "mask.png")
+	require.NoError(t, os.WriteFile(imagePath, png, 0o600))
+	require.NoError(t, os.WriteFile(maskPath, png, 0o600))
+
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/images/edits", r.URL.Path)
+		assert.NoError(t, r.ParseMultipartForm(10<<20))
+		assert.Equal(t, "gpt-image-1", r.FormValue("model"))
+		assert.Equal(t, "add a hat", r.FormValue("prompt"))
+
+		file, header, err := r.FormFile("image")
+		assert.NoError(t, err)
+		defer file.Close()
+		assert.Equal(t, "product.png", header.Filename)
+		assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, png, data)
+
+		_, mask, err := r.FormFile("mask")
+		assert.NoError(t, err)
+		assert.Equal(t, "mask.png", mask.Filename)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"created":1,"data":[{"url":"https://example.com/a.png"}]}`))
+	})
+
+	res, err := clientInstance.ImageEdit(context.Background(), models.ModelMeta{ModelId: "gpt-image-1"}, request.ImageEdit{
+		Prompt: "add a hat",
+		Images: []string{imagePath},
+		Mask:   maskPath,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a.png", res.Data[0].URL)
+
+	_, err = res.Data[0].Save(dir)
+	assert.ErrorContains(t, err, "no b64_json data")
+}
+
+func TestImageVariation_Error(t *testing.T) {
+	dir := t.TempDir()
+	imagePath := filepath.Join(dir, "a.png")
+	require.NoError(t, os.WriteFile(imagePath, []byte("not really png"), 0o600))
+
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/images/variations", r.URL.Path)
+		assert.NoError(t, r.ParseMultipartForm(10<<20))
+		assert.Equal(t, "3", r.FormValue("n"))
+
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad image"}}`))
+	})
+
+	_, err := clientInstance.ImageVariation(context.Background(), models.ModelMeta{ModelId: "dall-e-2"}, request.ImageVariation{Image: imagePath, N: 3})
+	assert.ErrorContains(t, err, "bad image")
+}
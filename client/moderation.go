@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/opus-domini/fast-shot/constant/mime"
+
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// Moderation classifies inputs for harmful content.
+func (l *Litellm) Moderation(ctx context.Context, model models.ModelMeta, req request.Moderation) (response.ModerationResponse, error) {
+	if err := checkMode(model, models.MODE_MODERATION); err != nil {
+		return response.ModerationResponse{}, err
+	}
+	if len(req.Input) == 0 && len(req.Contents) == 0 {
+		return response.ModerationResponse{}, fmt.Errorf("input cannot be empty")
+	}
+	req.Model = model.ModelId
+
+	target := l.Connection.Targets.Get(cfg.CLIENT_LLM)
+	resp, err := l.client(cfg.CLIENT_LLM).
+		POST("/moderations").
+		Context().Set(ctx).
+		Header().AddAccept(mime.JSON).
+		Retry().SetExponentialBackoff(
+		target.RetryInterval,
+		target.RetryMaxAttempts,
+		target.RetryBackoffRate).
+		Body().AsJSON(req).
+		Send()
+
+	if err != nil {
+		return response.ModerationResponse{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body().Close()
+
+	var res response.ModerationResponse
+	err = httpresp.ParseHTTPResponse(*resp, &res)
+	if err != nil {
+		return response.ModerationResponse{}, fmt.Errorf("failed to parse moderation response: %w", err)
+	}
+
+	return res, nil
+}
+
+// ModerationGuard screens the latest user message (text and images) before Completion, Responses or Messages sends it.
+// Set it on Litellm.ModerationGuard to enable it. A failed moderation call blocks the completion too.
+type ModerationGuard struct {
+	Model models.ModelMeta
+	// Thresholds blocks when a category score reaches its value, other categories are ignored.
+	// Without thresholds the call is blocked when the moderation model flags the input.
+	Thresholds map[response.ModerationCategory]float64
+}
+
+// ModerationError is returned by Completion, Responses and Messages when the ModerationGuard blocked the request.
+type ModerationError struct {
+	// Categories that exceeded their threshold, with their scores.
+	Categories map[response.ModerationCategory]float64
+	Result     response.ModerationResult
+}
+
+func (e *ModerationError) Error() string {
+	categories := make([]string, 0, len(e.Categories))
+	for category, score := range e.Categories {
+		categories = append(categories, fmt.Sprintf("%s (%.2f)", category, score))
+	}
+	slices.Sort(categories)
+	return "blocked by moderation: " + strings.Join(categories, ", ")
+}
+
+// Moderate runs the ModerationGuard on req the way Completion does, nil without a guard.
+// Caches in front of the client call it before they return a cached response.
+func (l *Litellm) Moderate(ctx context.Context, req *request.Request) error {
+	return l.moderate(ctx, req.ModerationInputs())
+}
+
+// moderate runs the guard on the latest user inputs, see request.Request.ModerationInputs.
+func (l *Litellm) moderate(ctx context.Context, inputs []request.ModerationInput) error {
+	guard := l.ModerationGuard
+	if guard == nil || len(inputs) == 0 {
+		return nil
+	}
+
+	res, err := l.Moderation(ctx, guard.Model, request.Moderation{Contents: inputs})
+	if err != nil {
+		return fmt.Errorf("moderation guard failed: %w", err)
+	}
+	for _, result := range res.Results {
+		exceeding := result.Exceeding(guard.Thresholds)
+		if len(guard.Thresholds) == 0 && result.Flagged && len(exceeding) == 0 {
+			// flagged without category details
+			exceeding[response.ModerationCategory("flagged")] = 1
+		}
+		if len(exceeding) > 0 {
+			return &ModerationError{Categories: exceeding, Result: result}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+const moderationResponse = `{"id":"modr-1","model":"omni-moderation-latest","results":[{
+	"flagged":true,
+	"categories":{"violence":true,"harassment":false},
+	"category_scores":{"violence":0.91,"harassment":0.32},
+	"category_applied_input_types":{"violence":["image"],"harassment":["text"]}
+}]}`
+
+func TestModeration(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/moderations", r.URL.Path)
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "omni-moderation-latest", body["model"])
+		assert.Equal(t, []any{"first", "second"}, body["input"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(moderationResponse))
+	})
+
+	model := models.ModelMeta{ModelId: "omni-moderation-latest", Mode: models.MODE_MODERATION}
+	res, err := clientInstance.Moderation(context.Background(), model, request.Moderation{Input: []string{"first", "second"}})
+	require.NoError(t, err)
+	assert.True(t, res.Flagged())
+	require.Len(t, res.Results, 1)
+	assert.True(t, res.Results[0].Categories[response.MODERATION_VIOLENCE])
+	assert.Equal(t, 0.32, res.Results[0].CategoryScores[response.MODERATION_HARASSMENT])
+	assert.Equal(t, []string{"image"}, res.Results[0].CategoryAppliedInputTypes[response.MODERATION_VIOLENCE])
+
+	_, err = clientInstance.Moderation(context.Background(), model, request.Moderation{})
+	assert.ErrorContains(t, err, "input cannot be empty")
+}
+
+func TestCompletion_ModerationGuard(t *testing.T) {
+	completions := 0
+	var moderated []any
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/moderations" {
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			moderated = body["input"].([]any)
+			_, _ = w.Write([]byte(moderationResponse))
+			return
+		}
+		completions++
+		_, _ = w.Write([]byte(`{"id":"1","choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	})
+	clientInstance.ModerationGuard = &client.ModerationGuard{
+		Model:      models.ModelMeta{ModelId: "omni-moderation-latest"},
+		Thresholds: map[response.ModerationCategory]float64{response.MODERATION_VIOLENCE: 0.9},
+	}
+
+	req := request.NewRequest(models.ModelMeta{ModelId: "gpt-4o"})
+	req.SetMessages(request.Messages{
+		request.UserMessageSimple("earlier message is not moderated"),
+		request.AssistantMessageSimple("sure"),
+		request.UserMessageImage("what is this?", request.MessageImage("https://example.com/a.png")),
+	})
+
+	_, err := clientInstance.Completion(context.Background(), req)
+	var blocked *client.ModerationError
+	require.True(t, errors.As(err, &blocked))
+	assert.Equal(t, map[response.ModerationCategory]float64{response.MODERATION_VIOLENCE: 0.91}, blocked.Categories)
+	assert.EqualError(t, err, "blocked by moderation: violence (0.91)")
+	assert.Equal(t, []any{
+		map[string]any{"type": "text", "text": "what is this?"},
+		map[string]any{"type": "image_url", "image_url": map[string]any{"url": "https://example.com/a.png"}},
+	}, moderated)
+	assert.Zero(t, completions)
+
+	// scores under the thresholds pass
+	clientInstance.ModerationGuard.Thresholds = map[response.ModerationCategory]float64{response.MODERATION_HARASSMENT: 0.5}
+	res, err := clientInstance.Completion(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", res.String())
+	assert.Equal(t, 1, completions)
+
+	// without thresholds the flagged categories block
+	clientInstance.ModerationGuard.Thresholds = nil
+	_, err = clientInstance.Completion(context.Background(), req)
+	require.True(t, errors.As(err, &blocked))
+	assert.Equal(t, map[response.ModerationCategory]float64{response.MODERATION_VIOLENCE: 0.91}, blocked.Categories)
+
+	// an empty map behaves like no thresholds
+	clientInstance.ModerationGuard.Thresholds = map[response.ModerationCategory]float64{}
+	_, err = clientInstance.Completion(context.Background(), req)
+	require.True(t, errors.As(err, &blocked))
+	assert.Equal(t, map[response.ModerationCategory]float64{response.MODERATION_VIOLENCE: 0.91}, blocked.Categories)
+	assert.Equal(t, 1, completions)
+}
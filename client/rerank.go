@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opus-domini/fast-shot/constant/mime"
+
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// Rerank orders documents by relevance to query. topN limits the results, 0 returns all.
+// Use response.Rank to reorder your own items by the result.
+func (l *Litellm) Rerank(ctx context.Context, model models.ModelMeta, query string, documents []string, topN int) (response.RerankResponse, error) {
+	return l.RerankRequest(ctx, model, request.Rerank{Query: query, Documents: documents, TopN: topN})
+}
+
+// RerankRequest is Rerank with all request options, e.g. ReturnDocuments.
+func (l *Litellm) RerankRequest(ctx context.Context, model models.ModelMeta, req request.Rerank) (response.RerankResponse, error) {
+	if err := checkMode(model, models.MODE_RERANK); err != nil {
+		return response.RerankResponse{}, err
+	}
+	if req.Query == "" {
+		return response.RerankResponse{}, fmt.Errorf("query cannot be empty")
+	}
+	if len(req.Documents) == 0 {
+		return response.RerankResponse{}, fmt.Errorf("documents cannot be empty")
+	}
+	if req.TopN < 0 {
+		return response.RerankResponse{}, fmt.Errorf("top_n must not be negative")
+	}
+	req.Model = model.ModelId
+
+	target := l.Connection.Targets.Get(cfg.CLIENT_LLM)
+	resp, err := l.client(cfg.CLIENT_LLM).
+		POST("/rerank").
+		Context().Set(ctx).
+		Header().AddAccept(mime.JSON).
+		Retry().SetExponentialBackoff(
+		target.RetryInterval,
+		target.RetryMaxAttempts,
+		target.RetryBackoffRate).
+		Body().AsJSON(req).
+		Send()
+
+	if err != nil {
+		return response.RerankResponse{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body().Close()
+
+	var res response.RerankResponse
+	err = httpresp.ParseHTTPResponse(*resp, &res)
+	if err != nil {
+		return response.RerankResponse{}, fmt.Errorf("failed to parse rerank response: %w", err)
+	}
+
+	return res, nil
+}
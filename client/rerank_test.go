@@ -0,0 +1,76 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func TestRerank(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rerank", r.URL.Path)
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "cohere-rerank-v3.5", body["model"])
+		assert.Equal(t, "capital of france", body["query"])
+		assert.Equal(t, []any{"Berlin is in Germany", "Paris is the capital of France", "France borders Spain"}, body["documents"])
+		assert.Equal(t, float64(2), body["top_n"])
+		assert.Equal(t, true, body["return_documents"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"rr-1","results":[
+			{"index":1,"relevance_score":0.98,"document":{"text":"Paris is the capital of France"}},
+			{"index":2,"relevance_score":0.41,"document":"France borders Spain"}
+		],"meta":{"billed_units":{"search_units":1}}}`))
+	})
+
+	model := models.ModelMeta{ModelId: "cohere-rerank-v3.5", Mode: models.MODE_RERANK}
+	res, err := clientInstance.RerankRequest(context.Background(), model, request.Rerank{
+		Query:           "capital of france",
+		Documents:       []string{"Berlin is in Germany", "Paris is the capital of France", "France borders Spain"},
+		TopN:            2,
+		ReturnDocuments: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rr-1", res.ID)
+	require.Len(t, res.Results, 2)
+	assert.Equal(t, 1, res.Results[0].Index)
+	assert.Equal(t, 0.98, res.Results[0].RelevanceScore)
+	assert.Equal(t, "Paris is the capital of France", res.Results[0].Document.Text)
+	assert.Equal(t, "France borders Spain", res.Results[1].Document.Text)
+	assert.Equal(t, 1, res.Meta.BilledUnits.SearchUnits)
+
+	type doc struct{ ID string }
+	ranked, err := response.Rank([]doc{{"berlin"}, {"paris"}, {"spain"}}, res.Results)
+	require.NoError(t, err)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "paris", ranked[0].Item.ID)
+	assert.Equal(t, "spain", ranked[1].Item.ID)
+}
+
+func TestRerank_Validation(t *testing.T) {
+	clientInstance := client.Litellm{Config: getConfig(), Connection: getConn()}
+	model := models.ModelMeta{ModelId: "jina-reranker"}
+
+	_, err := clientInstance.Rerank(context.Background(), models.ModelMeta{ModelId: "gpt-4o", Mode: models.MODE_CHAT}, "q", []string{"d"}, 0)
+	assert.ErrorContains(t, err, `model "gpt-4o" has mode "chat", expected "rerank"`)
+
+	_, err = clientInstance.Rerank(context.Background(), model, "", []string{"d"}, 0)
+	assert.ErrorContains(t, err, "query cannot be empty")
+
+	_, err = clientInstance.Rerank(context.Background(), model, "q", nil, 0)
+	assert.ErrorContains(t, err, "documents cannot be empty")
+
+	_, err = clientInstance.Rerank(context.Background(), model, "q", []string{"d"}, -1)
+	assert.ErrorContains(t, err, "top_n must not be negative")
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+	"github.com/andrejsstepanovs/go-litellm/sse"
+)
+
+// Responses sends a request to /v1/responses. Use request.Request.ToResponses to convert a chat request
+// and response.ResponsesResponse.ToResponse to convert the result back.
+func (l *Litellm) Responses(ctx context.Context, req *request.Responses) (response.ResponsesResponse, error) {
+	if err := l.checkResponses(ctx, req); err != nil {
+		return response.ResponsesResponse{}, err
+	}
+	body := *req
+	body.Stream = false
+
+	res, err := sendJSON[response.ResponsesResponse](ctx, l, l.client(cfg.CLIENT_LLM).POST("/v1/responses"), body)
+	if err != nil {
+		return response.ResponsesResponse{}, fmt.Errorf("failed to get responses result: %w", err)
+	}
+	if res.Status == response.RESPONSES_STATUS_FAILED {
+		return res, responsesFailed(&res)
+	}
+	return res, nil
+}
+
+// ResponsesStream streams a response and calls fn for every event, fn is optional.
+// It returns the final response of the "response.completed" or "response.incomplete" event.
+func (l *Litellm) ResponsesStream(ctx context.Context, req *request.Responses, fn func(response.ResponsesEvent) error) (response.ResponsesResponse, error) {
+	if err := l.checkResponses(ctx, req); err != nil {
+		return response.ResponsesResponse{}, err
+	}
+	body := *req
+	body.Stream = true
+
+	resp, err := l.postStream(ctx, "/v1/responses", body, nil)
+	if err != nil {
+		return response.ResponsesResponse{}, err
+	}
+	defer closeBody(resp)
+
+	var final *response.ResponsesResponse
+	err = sse.Read(resp.Body, func(e sse.Event) error {
+		var event response.ResponsesEvent
+		if err := json.Unmarshal(e.Data, &event); err != nil {
+			return fmt.Errorf("failed to parse responses event: %w", err)
+		}
+		if fn != nil {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+
+		switch event.Type {
+		case response.RESPONSES_EVENT_COMPLETED, response.RESPONSES_EVENT_INCOMPLETE:
+			final = event.Response
+		case response.RESPONSES_EVENT_FAILED:
+			if event.Response == nil {
+				return errors.New("response failed")
+			}
+			final = event.Response
+			return responsesFailed(event.Response)
+		case response.RESPONSES_EVENT_ERROR:
+			return fmt.Errorf("responses stream error: %s: %s", event.Code, event.Message)
+		}
+		return nil
+	})
+	if final == nil {
+		final = &response.ResponsesResponse{}
+	}
+	if err != nil {
+		return *final, err
+	}
+	if final.ID == "" {
+		return *final, errors.New("responses stream ended before the response completed")
+	}
+	return *final, nil
+}
+
+// checkResponses validates req and runs the ModerationGuard, like Completion does.
+func (l *Litellm) checkResponses(ctx context.Context, req *request.Responses) error {
+	if req == nil {
+		return errors.New("responses request cannot be nil")
+	}
+	if req.Model == "" {
+		return errors.New("model cannot be empty")
+	}
+	if len(req.Input) == 0 {
+		return errors.New("input cannot be empty")
+	}
+	if err := req.ValidateToolChoice(); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	return l.moderate(ctx, req.ModerationInputs())
+}
+
+func responsesFailed(res *response.ResponsesResponse) error {
+	if res.Error == nil {
+		return fmt.Errorf("response %s failed", res.ID)
+	}
+	return fmt.Errorf("response %s failed: %s: %s", res.ID, res.Error.Code, res.Error.Message)
+}
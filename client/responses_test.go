@@ -0,0 +1,181 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func responsesRequest() *request.Responses {
+	return &request.Responses{
+		Model:              "gpt-5-mini",
+		Input:              []request.ResponsesInputItem{{Type: request.RESPONSES_ITEM_MESSAGE, Role: request.ROLE_USER, Content: []request.ResponsesContent{{Type: request.RESPONSES_CONTENT_INPUT_TEXT, Text: "hi"}}}},
+		PreviousResponseID: "resp-0",
+	}
+}
+
+func TestResponses(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/responses", r.URL.Path)
+		assert.Equal(t, "Bearer sk-1234", r.Header.Get("Authorization"))
+
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "gpt-5-mini", body["model"])
+		assert.Equal(t, "resp-0", body["previous_response_id"])
+		assert.NotContains(t, body, "stream")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"resp-1","object":"response","status":"completed","model":"gpt-5-mini",
+			"output":[{"type":"message","id":"msg-1","role":"assistant","content":[{"type":"output_text","text":"hello"}]}],
+			"usage":{"input_tokens":5,"output_tokens":1,"total_tokens":6}}`))
+	})
+
+	res, err := clientInstance.Responses(context.Background(), responsesRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "resp-1", res.ID)
+	assert.Equal(t, "hello", res.OutputText())
+	assert.Equal(t, 6, res.Usage.TotalTokens)
+
+	_, err = clientInstance.Responses(context.Background(), &request.Responses{Model: "gpt-5-mini"})
+	assert.ErrorContains(t, err, "input cannot be empty")
+}
+
+func TestResponses_Failed(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"resp-1","status":"failed","error":{"code":"server_error","message":"boom"}}`))
+	})
+
+	_, err := clientInstance.Responses(context.Background(), responsesRequest())
+	assert.EqualError(t, err, "response resp-1 failed: server_error: boom")
+}
+
+func writeEvents(w http.ResponseWriter, events ...string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	for _, event := range events {
+		var typed struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal([]byte(event), &typed)
+		var data bytes.Buffer
+		_ = json.Compact(&data, []byte(event))
+		_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", typed.Type, data.String())
+		w.(http.Flusher).Flush()
+	}
+}
+
+func TestResponsesStream(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		var body map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, true, body["stream"])
+
+		writeEvents(w,
+			`{"type":"response.created","sequence_number":0,"response":{"id":"resp-1","status":"in_progress"}}`,
+			`{"type":"response.output_text.delta","sequence_number":1,"item_id":"msg-1","delta":"hel"}`,
+			`{"type":"response.output_text.delta","sequence_number":2,"item_id":"msg-1","delta":"lo"}`,
+			`{"type":"response.completed","sequence_number":3,"response":{"id":"resp-1","status":"completed",
+				"output":[{"type":"message","id":"msg-1","role":"assistant","content":[{"type":"output_text","text":"hello"}]}]}}`,
+		)
+	})
+
+	var deltas string
+	var types []string
+	res, err := clientInstance.ResponsesStream(context.Background(), responsesRequest(), func(event response.ResponsesEvent) error {
+		types = append(types, event.Type)
+		if event.Type == response.RESPONSES_EVENT_OUTPUT_TEXT_DELTA {
+			deltas += event.Delta
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hello", deltas)
+	assert.Equal(t, "hello", res.OutputText())
+	assert.Equal(t, response.RESPONSES_STATUS_COMPLETED, res.Status)
+	assert.Len(t, types, 4)
+}
+
+func TestResponsesStream_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []string
+		err    string
+	}{
+		{
+			name:   "failed",
+			events: []string{`{"type":"response.failed","response":{"id":"resp-1","status":"failed","error":{"code":"rate_limit_exceeded","message":"slow down"}}}`},
+			err:    "response resp-1 failed: rate_limit_exceeded: slow down",
+		},
+		{
+			name:   "error event",
+			events: []string{`{"type":"error","code":"invalid_prompt","message":"bad input"}`},
+			err:    "responses stream error: invalid_prompt: bad input",
+		},
+		{
+			name:   "truncated",
+			events: []string{`{"type":"response.output_text.delta","delta":"hel"}`},
+			err:    "responses stream ended before the response completed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeEvents(w, tt.events...)
+			})
+			_, err := clientInstance.ResponsesStream(context.Background(), responsesRequest(), nil)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestResponsesStream_HTTPError(t *testing.T) {
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"unknown model"}}`))
+	})
+
+	_, err := clientInstance.ResponsesStream(context.Background(), responsesRequest(), nil)
+	assert.ErrorContains(t, err, "unknown model")
+}
+
+func TestResponses_Guard(t *testing.T) {
+	calls := 0
+	clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/moderations" {
+			_, _ = w.Write([]byte(moderationResponse))
+			return
+		}
+		calls++
+		_, _ = w.Write([]byte(`{"id":"resp-1","status":"completed"}`))
+	})
+
+	req := responsesRequest()
+	rc := request.ResponsesToolChoice(request.ToolChoiceFunction("get_weather"))
+	req.ToolChoice = &rc
+	_, err := clientInstance.Responses(context.Background(), req)
+	assert.ErrorContains(t, err, `invalid request: tool_choice function "get_weather" is not in request tools`)
+
+	clientInstance.ModerationGuard = &client.ModerationGuard{Model: models.ModelMeta{ModelId: "omni-moderation-latest"}}
+	_, err = clientInstance.Responses(context.Background(), responsesRequest())
+	var blocked *client.ModerationError
+	require.True(t, errors.As(err, &blocked))
+	_, err = clientInstance.ResponsesStream(context.Background(), responsesRequest(), nil)
+	require.True(t, errors.As(err, &blocked))
+	assert.Zero(t, calls)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// TextToSpeechLong synthesizes input longer than the model limit into one file.
+// The input is split at paragraph and sentence boundaries, chunks are synthesized
+// concurrently and joined in order. Joining several chunks needs a wav, pcm, mp3 or aac ResponseFormat.
+func (l *Litellm) TextToSpeechLong(ctx context.Context, speechRequest request.Speech, chunking audio.SpeechChunkOptions, options ...audio.SpeechFileOption) (response.Speech, error) {
+	chunks := chunking.SplitSpeech(speechRequest.Input)
+	if len(chunks) == 0 {
+		return response.Speech{}, fmt.Errorf("speech input is empty")
+	}
+	format := speechExtension(speechRequest)
+	if len(chunks) > 1 {
+		if err := audio.CheckConcatFormat(format); err != nil {
+			return response.Speech{}, err
+		}
+	}
+
+	parts, err := l.synthesizeChunks(ctx, speechRequest, chunks, chunking.Concurrency)
+	if err != nil {
+		return response.Speech{}, err
+	}
+
+	return writeSpeechFile(speechRequest, options, func(w io.Writer) error {
+		return audio.ConcatSpeech(w, format, parts)
+	})
+}
+
+func (l *Litellm) synthesizeChunks(ctx context.Context, speechRequest request.Speech, chunks []string, concurrency int) ([][]byte, error) {
+	parts := make([][]byte, len(chunks))
+	err := runConcurrent(ctx, len(chunks), concurrency, func(ctx context.Context, i int) error {
+		chunkRequest := speechRequest
+		chunkRequest.Input = chunks[i]
+
+		var buf bytes.Buffer
+		_, err := l.TextToSpeechStream(ctx, chunkRequest, &buf)
+		if err != nil {
+			return fmt.Errorf("failed to synthesize chunk %d of %d: %w", i+1, len(chunks), err)
+		}
+		parts[i] = buf.Bytes()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
@@ -7,12 +7,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/andrejsstepanovs/go-litellm/audio"
 	"github.com/andrejsstepanovs/go-litellm/client"
 	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
 )
 
 func TestSpeechToText(t *testing.T) {
@@ -59,6 +62,26 @@ func TestSpeechToText(t *testing.T) {
 		})
 	}
 
+	t.Run("unknown extra body response_format", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, r.ParseMultipartForm(10<<20))
+			assert.Equal(t, "diarized_json", r.FormValue("response_format"))
+			_, _ = w.Write([]byte(`{"text":"hello world"}`))
+		}))
+		defer server.Close()
+
+		testUrl, err := url.Parse(server.URL)
+		assert.NoError(t, err)
+
+		conn := getConn()
+		conn.URL = *testUrl
+		clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+		res, err := clientInstance.SpeechToText(context.Background(), models.ModelMeta{ModelId: "whisper-1"}, "testdata/file_174.oga", map[string]any{"response_format": "diarized_json"})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", res.Text)
+	})
+
 	t.Run("http error", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "bad request", http.StatusBadRequest)
@@ -79,6 +102,115 @@ func TestSpeechToText(t *testing.T) {
 	})
 }
 
+func TestTranscribe_ResponseFormats(t *testing.T) {
+	testCases := []struct {
+		name         string
+		endpoint     string
+		options      request.Transcription
+		body         string
+		expectedText string
+		segments     int
+	}{
+		{
+			name:         "transcription text",
+			endpoint:     "/audio/transcriptions",
+			options:      request.Transcription{ResponseFormat: request.TRANSCRIPTION_FORMAT_TEXT},
+			body:         "hello world\n",
+			expectedText: "hello world",
+		},
+		{
+			name:         "transcription srt",
+			endpoint:     "/audio/transcriptions",
+			options:      request.Transcription{ResponseFormat: request.TRANSCRIPTION_FORMAT_SRT},
+			body:         "1\n00:00:00,000 --> 00:00:01,000\nhello\n\n2\n00:00:01,000 --> 00:00:02,000\nworld\n",
+			expectedText: "hello world",
+			segments:     2,
+		},
+		{
+			name:         "translation json",
+			endpoint:     "/audio/translations",
+			options:      request.Transcription{},
+			body:         `{"text":"good morning"}`,
+			expectedText: "good morning",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tc.endpoint, r.URL.Path)
+				assert.NoError(t, r.ParseMultipartForm(10<<20))
+				assert.Equal(t, tc.options.ResponseFormat, r.FormValue("response_format"))
+
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			testUrl, err := url.Parse(server.URL)
+			assert.NoError(t, err)
+
+			conn := getConn()
+			conn.URL = *testUrl
+			clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+			model := models.ModelMeta{ModelId: testSTTOne}
+			call := clientInstance.Transcribe
+			if tc.endpoint == "/audio/translations" {
+				call = clientInstance.Translate
+			}
+			res, err := call(context.Background(), model, "testdata/file_174.oga", tc.options)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedText, res.Text)
+			assert.Len(t, res.Segments, tc.segments)
+		})
+	}
+
+	t.Run("invalid options", func(t *testing.T) {
+		clientInstance := client.Litellm{Config: getConfig(), Connection: getConn()}
+		_, err := clientInstance.Transcribe(context.Background(), models.ModelMeta{ModelId: testSTTOne}, "testdata/file_174.oga", request.Transcription{ResponseFormat: "xml"})
+		assert.ErrorContains(t, err, "unsupported transcription response_format")
+	})
+}
+
+func TestTranscribeReader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/audio/transcriptions", r.URL.Path)
+		assert.NoError(t, r.ParseMultipartForm(10<<20))
+		_, header, err := r.FormFile("file")
+		assert.NoError(t, err)
+		assert.Equal(t, "recording.oga", header.Filename)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"text":"hello world"}`))
+	}))
+	defer server.Close()
+
+	testUrl, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	conn := getConn()
+	conn.URL = *testUrl
+	clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+	file, err := os.Open("testdata/file_174.oga")
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var sent int64
+	res, err := clientInstance.TranscribeReader(context.Background(), models.ModelMeta{ModelId: testSTTOne}, audio.Upload{
+		Reader:   file,
+		Filename: "recording.oga",
+		Progress: func(s, _ int64) { sent = s },
+	}, request.Transcription{})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", res.Text)
+
+	info, err := file.Stat()
+	assert.NoError(t, err)
+	assert.Equal(t, info.Size(), sent)
+}
+
 func TestSpeechToText_Functional(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping functional test in short mode")
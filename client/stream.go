@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	cfg "github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
+)
+
+// postStream posts body and returns the open event stream. The caller closes the body.
+// The target timeout is not applied, long generations are bound by ctx only. headers are optional.
+func (l *Litellm) postStream(ctx context.Context, path string, body any, headers map[string]string) (*http.Response, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.Connection.URL.String()+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpresp.SetExtraHeaders(req, l.Config.ExtraHeaders)
+	req.Header.Set("Authorization", "Bearer "+l.Config.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("User-Agent", string(cfg.CLIENT_LLM))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer closeBody(resp)
+		msg, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read error response (status %d): %w", resp.StatusCode, err)
+		}
+		return nil, errors.New(string(msg))
+	}
+	return resp, nil
+}
+
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		log.Printf("Warning: failed to close response body: %v", err)
+	}
+}
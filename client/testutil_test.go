@@ -1,9 +1,14 @@
 package client_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/andrejsstepanovs/go-litellm/client"
 	"github.com/andrejsstepanovs/go-litellm/conf/connections/litellm"
 	"github.com/andrejsstepanovs/go-litellm/models"
@@ -52,3 +57,17 @@ func getConfig() client.Config {
 		Temperature: 0,
 	}
 }
+
+// newTestServerClient returns a client for an httptest server running handler, the server is closed on cleanup.
+func newTestServerClient(t *testing.T, handler http.HandlerFunc) *client.Litellm {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	testUrl, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	conn := getConn()
+	conn.URL = *testUrl
+	return &client.Litellm{Config: getConfig(), Connection: conn}
+}
@@ -1,15 +1,28 @@
 package client_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/andrejsstepanovs/go-litellm/audio"
 	"github.com/andrejsstepanovs/go-litellm/client"
 	"github.com/andrejsstepanovs/go-litellm/models"
 	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
 )
 
 func TestTextToSpeech_Functional(t *testing.T) {
@@ -67,3 +80,158 @@ func TestTextToSpeech_Functional(t *testing.T) {
 		})
 	}
 }
+
+func TestTextToSpeechStream(t *testing.T) {
+	t.Run("audio", func(t *testing.T) {
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/audio/speech", r.URL.Path)
+			var req request.Speech
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "hello", req.Input)
+
+			w.Header().Set("Content-Type", "audio/mpeg")
+			for _, part := range []string{"ID3", "frame1", "frame2"} {
+				_, _ = w.Write([]byte(part))
+				w.(http.Flusher).Flush()
+			}
+		})
+
+		var out bytes.Buffer
+		n, err := clientInstance.TextToSpeechStream(context.Background(), request.Speech{Model: "tts-1", Input: "hello", Voice: "alloy"}, &out)
+		require.NoError(t, err)
+		assert.Equal(t, int64(15), n)
+		assert.Equal(t, "ID3frame1frame2", out.String())
+	})
+
+	t.Run("sse", func(t *testing.T) {
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			var req request.Speech
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, audio.SPEECH_STREAM_FORMAT_SSE, req.StreamFormat)
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, part := range []string{"RIFF", "data"} {
+				_, _ = fmt.Fprintf(w, "data: {\"type\":\"speech.audio.delta\",\"audio\":%q}\n\n", base64.StdEncoding.EncodeToString([]byte(part)))
+				w.(http.Flusher).Flush()
+			}
+			_, _ = fmt.Fprint(w, "data: {\"type\":\"speech.audio.done\"}\n\n")
+		})
+
+		var out bytes.Buffer
+		n, err := clientInstance.TextToSpeechStream(context.Background(), request.Speech{
+			Model: "gpt-4o-mini-tts", Input: "hello", Voice: "alloy", ResponseFormat: "wav", StreamFormat: audio.SPEECH_STREAM_FORMAT_SSE,
+		}, &out)
+		require.NoError(t, err)
+		assert.Equal(t, int64(8), n)
+		assert.Equal(t, "RIFFdata", out.String())
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unknown voice", http.StatusBadRequest)
+		})
+
+		_, err := clientInstance.TextToSpeechStream(context.Background(), request.Speech{Model: "tts-1", Input: "hello"}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "speech API returned status 400")
+	})
+}
+
+func TestTextToSpeech_Output(t *testing.T) {
+	t.Run("directory and filename", func(t *testing.T) {
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("opus data"))
+		})
+
+		dir := filepath.Join(t.TempDir(), "speech")
+		res, err := clientInstance.TextToSpeech(context.Background(),
+			request.Speech{Model: "tts-1", Input: "hello", ResponseFormat: "opus"},
+			audio.SpeechDirectory(dir), audio.SpeechFilename("greeting"),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, response.Speech{
+			Name:      "greeting.opus",
+			Directory: dir,
+			Full:      filepath.Join(dir, "greeting.opus"),
+			Extension: "opus",
+		}, res)
+
+		data, err := os.ReadFile(res.Full)
+		require.NoError(t, err)
+		assert.Equal(t, "opus data", string(data))
+	})
+
+	t.Run("failed request removes file", func(t *testing.T) {
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+		})
+
+		dir := t.TempDir()
+		_, err := clientInstance.TextToSpeech(context.Background(), request.Speech{Model: "tts-1", Input: "hello"}, audio.SpeechDirectory(dir))
+		assert.ErrorContains(t, err, "speech API returned status 503")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestTextToSpeechLong(t *testing.T) {
+	t.Run("chunks are joined in order", func(t *testing.T) {
+		var calls atomic.Int32
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			var req request.Speech
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.LessOrEqual(t, len(req.Input), 20)
+			calls.Add(1)
+			if strings.HasPrefix(req.Input, "One") {
+				// the first chunk finishes last
+				time.Sleep(50 * time.Millisecond)
+			}
+			_, _ = w.Write([]byte("[" + req.Input + "]"))
+		})
+
+		dir := t.TempDir()
+		res, err := clientInstance.TextToSpeechLong(context.Background(),
+			request.Speech{Model: "tts-1", Input: "One sentence. Two sentence.\n\nThree sentence.", ResponseFormat: "pcm"},
+			audio.SpeechChunkOptions{MaxChars: 20, Concurrency: 3},
+			audio.SpeechDirectory(dir),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls.Load())
+		assert.Equal(t, "pcm", res.Extension)
+
+		data, err := os.ReadFile(res.Full)
+		require.NoError(t, err)
+		assert.Equal(t, "[One sentence.][Two sentence.][Three sentence.]", string(data))
+	})
+
+	t.Run("format cannot be joined", func(t *testing.T) {
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected")
+		})
+
+		_, err := clientInstance.TextToSpeechLong(context.Background(),
+			request.Speech{Model: "tts-1", Input: "One sentence. Two sentence.", ResponseFormat: "opus"},
+			audio.SpeechChunkOptions{MaxChars: 20},
+		)
+		assert.ErrorContains(t, err, `cannot concatenate "opus" audio`)
+	})
+
+	t.Run("chunk error", func(t *testing.T) {
+		clientInstance := newTestServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "input too long", http.StatusBadRequest)
+		})
+
+		dir := t.TempDir()
+		_, err := clientInstance.TextToSpeechLong(context.Background(),
+			request.Speech{Model: "tts-1", Input: "One sentence. Two sentence."},
+			audio.SpeechChunkOptions{MaxChars: 20},
+			audio.SpeechDirectory(dir),
+		)
+		assert.ErrorContains(t, err, "failed to synthesize chunk")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+// TranscribeLong transcribes audio longer than the provider upload limits.
+// WAV files (or raw PCM when chunking.PCMFormat is set) are split into chunks,
+// transcribed concurrently and merged into one response with absolute timestamps.
+// Other formats are uploaded in one piece.
+func (l *Litellm) TranscribeLong(ctx context.Context, model models.ModelMeta, audioFile string, options request.Transcription, chunking audio.ChunkOptions) (audio.AudioResponse, error) {
+	err := options.Validate()
+	if err != nil {
+		return audio.AudioResponse{}, err
+	}
+
+	file, err := os.Open(audioFile)
+	if err != nil {
+		return audio.AudioResponse{}, fmt.Errorf("error opening file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Warning: failed to close audio file: %v", err)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return audio.AudioResponse{}, fmt.Errorf("error reading file info: %w", err)
+	}
+
+	head := make([]byte, 12)
+	n, _ := file.ReadAt(head, 0)
+
+	var wav *audio.WAV
+	switch {
+	case audio.IsWAV(head[:n]):
+		wav, err = audio.ReadWAV(file, info.Size())
+	case chunking.PCMFormat != nil:
+		wav, err = audio.NewPCM(file, info.Size(), *chunking.PCMFormat)
+	default:
+		upload := audio.Upload{Reader: file, Filename: filepath.Base(audioFile), Size: info.Size()}
+		return l.transcribe(ctx, "audio/transcriptions", model, upload, options)
+	}
+	if err != nil {
+		return audio.AudioResponse{}, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	chunks, err := wav.Chunks(chunking)
+	if err != nil {
+		return audio.AudioResponse{}, err
+	}
+
+	results, err := l.transcribeChunks(ctx, model, wav, chunks, options, chunking.Concurrency)
+	if err != nil {
+		return audio.AudioResponse{}, err
+	}
+
+	return audio.MergeChunks(results), nil
+}
+
+func (l *Litellm) transcribeChunks(ctx context.Context, model models.ModelMeta, wav *audio.WAV, chunks []audio.Chunk, options request.Transcription, concurrency int) ([]audio.ChunkResult, error) {
+	results := make([]audio.ChunkResult, len(chunks))
+	err := runConcurrent(ctx, len(chunks), concurrency, func(ctx context.Context, i int) error {
+		chunk := chunks[i]
+		upload := audio.Upload{Filename: fmt.Sprintf("chunk_%03d.wav", chunk.Index)}
+		upload.Reader, upload.Size = wav.Section(chunk.Start, chunk.End)
+
+		res, err := l.transcribe(ctx, "audio/transcriptions", model, upload, options)
+		if err != nil {
+			return fmt.Errorf("failed to transcribe chunk %d (%s - %s): %w", chunk.Index, chunk.Start, chunk.End, err)
+		}
+		results[i] = audio.ChunkResult{Chunk: chunk, Response: res}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,152 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/audio"
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+// writeSilentWAV writes a mono 16 bit 8kHz WAV file of the given length.
+func writeSilentWAV(t *testing.T, length time.Duration) string {
+	t.Helper()
+	const sampleRate = 8000
+	dataSize := uint32(length.Seconds() * sampleRate * 2)
+
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, 36+dataSize)
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, struct {
+		Size          uint32
+		Format        uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}{16, 1, 1, sampleRate, sampleRate * 2, 2, 16})
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, dataSize)
+	buf.Write(make([]byte, dataSize))
+
+	file := filepath.Join(t.TempDir(), "long.wav")
+	require.NoError(t, os.WriteFile(file, buf.Bytes(), 0o600))
+	return file
+}
+
+func TestTranscribeLong(t *testing.T) {
+	t.Run("wav is chunked", func(t *testing.T) {
+		var uploads atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/audio/transcriptions", r.URL.Path)
+			file, header, err := r.FormFile("file")
+			assert.NoError(t, err)
+			data, err := io.ReadAll(file)
+			assert.NoError(t, err)
+			assert.True(t, audio.IsWAV(data))
+
+			wav, err := audio.ReadWAV(bytes.NewReader(data), int64(len(data)))
+			assert.NoError(t, err)
+			uploads.Add(1)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, err = fmt.Fprintf(w, `{"text":"%s","segments":[{"id":0,"start":0,"end":%.1f,"text":"%s"}]}`,
+				header.Filename, wav.Duration().Seconds(), header.Filename)
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		testUrl, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		conn := getConn()
+		conn.URL = *testUrl
+		clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+		res, err := clientInstance.TranscribeLong(
+			context.Background(),
+			models.ModelMeta{ModelId: "whisper-1"},
+			writeSilentWAV(t, 25*time.Second),
+			request.Transcription{ResponseFormat: request.TRANSCRIPTION_FORMAT_VERBOSE_JSON},
+			audio.ChunkOptions{MaxDuration: 10 * time.Second, Overlap: -1, Concurrency: 2},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), uploads.Load())
+		assert.Equal(t, "chunk_000.wav chunk_001.wav chunk_002.wav", res.Text)
+		require.Len(t, res.Segments, 3)
+		assert.Equal(t, float32(20), res.Segments[2].Start)
+		assert.Equal(t, float32(25), res.Segments[2].End)
+		assert.Equal(t, float32(25), res.Duration)
+	})
+
+	t.Run("chunk error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "too large", http.StatusRequestEntityTooLarge)
+		}))
+		defer server.Close()
+
+		testUrl, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		conn := getConn()
+		conn.URL = *testUrl
+		clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+		_, err = clientInstance.TranscribeLong(
+			context.Background(),
+			models.ModelMeta{ModelId: "whisper-1"},
+			writeSilentWAV(t, 15*time.Second),
+			request.Transcription{},
+			audio.ChunkOptions{MaxDuration: 10 * time.Second},
+		)
+		assert.ErrorContains(t, err, "failed to transcribe chunk")
+	})
+
+	t.Run("other formats are uploaded whole", func(t *testing.T) {
+		var uploads atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, header, err := r.FormFile("file")
+			assert.NoError(t, err)
+			assert.Equal(t, "file_174.oga", header.Filename)
+			uploads.Add(1)
+
+			w.Header().Set("Content-Type", "application/json")
+			_, err = w.Write([]byte(`{"text":"hello world"}`))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+
+		testUrl, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		conn := getConn()
+		conn.URL = *testUrl
+		clientInstance := client.Litellm{Config: getConfig(), Connection: conn}
+
+		res, err := clientInstance.TranscribeLong(
+			context.Background(),
+			models.ModelMeta{ModelId: "whisper-1"},
+			"testdata/file_174.oga",
+			request.Transcription{},
+			audio.ChunkOptions{MaxDuration: time.Second},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), uploads.Load())
+		assert.Equal(t, "hello world", res.Text)
+	})
+}
@@ -0,0 +1,35 @@
+package common
+
+// "thinking_blocks": [
+//   {"type": "thinking", "thinking": "Let me check the time...", "signature": "EqoBCkgIARAB..."},
+//   {"type": "redacted_thinking", "data": "EmwKAhgBEgy3va..."}
+// ]
+
+const THINKING_BLOCK_THINKING = "thinking"
+const THINKING_BLOCK_REDACTED = "redacted_thinking"
+
+type ThinkingBlocks []ThinkingBlock
+
+// ThinkingBlock is an Anthropic extended thinking block. Signature (or Data for
+// redacted blocks) must be sent back unchanged when replaying tool-use turns.
+type ThinkingBlock struct {
+	Type      string `json:"type"`
+	Thinking  string `json:"thinking,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Data      string `json:"data,omitempty"`
+}
+
+// Redacted returns a copy without readable thinking. Thinking blocks are dropped, as a signature
+// without its text is rejected by the api; redacted_thinking blocks are already opaque and kept.
+func (tb ThinkingBlocks) Redacted() ThinkingBlocks {
+	if tb == nil {
+		return nil
+	}
+	redacted := make(ThinkingBlocks, 0, len(tb))
+	for _, block := range tb {
+		if block.Type == THINKING_BLOCK_REDACTED {
+			redacted = append(redacted, block)
+		}
+	}
+	return redacted
+}
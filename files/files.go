@@ -0,0 +1,74 @@
+// Package files uploads files to the /v1/files endpoint.
+package files
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
+)
+
+// Upload streams r as a multipart request through an io.Pipe, so large batch
+// files are never held in memory as a whole.
+func Upload(ctx context.Context, url, token, purpose, filename string, r io.Reader, extraHeaders map[string]string) (*http.Response, error) {
+	if r == nil {
+		return nil, fmt.Errorf("file reader cannot be nil")
+	}
+	if filename == "" {
+		return nil, fmt.Errorf("filename cannot be empty")
+	}
+	if purpose == "" {
+		return nil, fmt.Errorf("purpose cannot be empty")
+	}
+
+	bodyReader, bodyWriter := io.Pipe()
+	writer := multipart.NewWriter(bodyWriter)
+
+	go func() {
+		bodyWriter.CloseWithError(writeMultipart(writer, purpose, filename, r))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bodyReader)
+	if err != nil {
+		_ = bodyReader.CloseWithError(err)
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpresp.SetExtraHeaders(req, extraHeaders)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		// unblock the writer goroutine if the request failed before the body was read
+		_ = bodyReader.CloseWithError(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func writeMultipart(writer *multipart.Writer, purpose, filename string, r io.Reader) error {
+	err := writer.WriteField("purpose", purpose)
+	if err != nil {
+		return fmt.Errorf("error writing purpose field: %w", err)
+	}
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return fmt.Errorf("error creating form file: %w", err)
+	}
+	_, err = io.Copy(part, r)
+	if err != nil {
+		return fmt.Errorf("error copying file content: %w", err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("error closing writer: %w", err)
+	}
+	return nil
+}
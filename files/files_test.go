@@ -0,0 +1,42 @@
+package files_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/files"
+)
+
+func TestUpload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "app", r.Header.Get("X-App"))
+		assert.Equal(t, "batch", r.FormValue("purpose"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		content, _ := io.ReadAll(file)
+		assert.Equal(t, "input.jsonl", header.Filename)
+		assert.Equal(t, "{\"custom_id\":\"a\"}\n", string(content))
+		_, _ = w.Write([]byte(`{"id":"file-1"}`))
+	}))
+	defer server.Close()
+
+	resp, err := files.Upload(context.Background(), server.URL, "token", "batch", "input.jsonl",
+		strings.NewReader("{\"custom_id\":\"a\"}\n"), map[string]string{"X-App": "app"})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = files.Upload(context.Background(), server.URL, "token", "batch", "", strings.NewReader(""), nil)
+	assert.ErrorContains(t, err, "filename cannot be empty")
+	_, err = files.Upload(context.Background(), server.URL, "token", "batch", "a.jsonl", nil, nil)
+	assert.ErrorContains(t, err, "file reader cannot be nil")
+}
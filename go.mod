@@ -8,6 +8,7 @@ require (
 	github.com/opus-domini/fast-shot v1.3.2
 	github.com/spf13/viper v1.21.0
 	github.com/stretchr/testify v1.11.1
+	go.etcd.io/bbolt v1.4.3
 )
 
 require (
@@ -0,0 +1,18 @@
+package httpresp
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SetExtraHeaders sets the custom headers of the client config on req, blank keys or values are skipped.
+func SetExtraHeaders(req *http.Request, extraHeaders map[string]string) {
+	for key, value := range extraHeaders {
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key == "" || value == "" {
+			continue
+		}
+		req.Header.Set(key, value)
+	}
+}
@@ -0,0 +1,158 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/andrejsstepanovs/go-litellm/httpresp"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+// Edit sends a multipart /images/edits request.
+func Edit(ctx context.Context, url, token string, editRequest request.ImageEdit, extraHeaders map[string]string) (*http.Response, error) {
+	if len(editRequest.Images) == 0 {
+		return nil, fmt.Errorf("at least one image is required")
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	fields := map[string]string{
+		"model":           string(editRequest.Model),
+		"prompt":          editRequest.Prompt,
+		"size":            editRequest.Size,
+		"quality":         editRequest.Quality,
+		"response_format": editRequest.ResponseFormat,
+		"user":            editRequest.User,
+	}
+	if editRequest.N > 0 {
+		fields["n"] = strconv.Itoa(editRequest.N)
+	}
+	err := writeFields(writer, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	imageField := "image"
+	if len(editRequest.Images) > 1 {
+		imageField = "image[]"
+	}
+	for _, imagePath := range editRequest.Images {
+		err = writeFile(writer, imageField, imagePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if editRequest.Mask != "" {
+		err = writeFile(writer, "mask", editRequest.Mask)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return send(ctx, url, token, writer, &body, extraHeaders)
+}
+
+// Variation sends a multipart /images/variations request.
+func Variation(ctx context.Context, url, token string, variationRequest request.ImageVariation, extraHeaders map[string]string) (*http.Response, error) {
+	if variationRequest.Image == "" {
+		return nil, fmt.Errorf("image is required")
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	fields := map[string]string{
+		"model":           string(variationRequest.Model),
+		"size":            variationRequest.Size,
+		"response_format": variationRequest.ResponseFormat,
+		"user":            variationRequest.User,
+	}
+	if variationRequest.N > 0 {
+		fields["n"] = strconv.Itoa(variationRequest.N)
+	}
+	err := writeFields(writer, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFile(writer, "image", variationRequest.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	return send(ctx, url, token, writer, &body, extraHeaders)
+}
+
+func writeFields(writer *multipart.Writer, fields map[string]string) error {
+	for key, value := range fields {
+		if value == "" {
+			continue
+		}
+		err := writer.WriteField(key, value)
+		if err != nil {
+			return fmt.Errorf("error writing %s field: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func writeFile(writer *multipart.Writer, field, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error opening file: %w", err)
+	}
+	defer file.Close()
+
+	// sniff the content type, providers reject application/octet-stream images
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return fmt.Errorf("error reading file %q: %w", filePath, err)
+	}
+
+	partHeader := make(textproto.MIMEHeader)
+	partHeader.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, field, filepath.Base(filePath)))
+	partHeader.Set("Content-Type", http.DetectContentType(head[:n]))
+	part, err := writer.CreatePart(partHeader)
+	if err != nil {
+		return fmt.Errorf("error creating form file: %w", err)
+	}
+
+	_, err = part.Write(head[:n])
+	if err != nil {
+		return fmt.Errorf("error copying file content: %w", err)
+	}
+	_, err = io.Copy(part, file)
+	if err != nil {
+		return fmt.Errorf("error copying file content: %w", err)
+	}
+	return nil
+}
+
+func send(ctx context.Context, url, token string, writer *multipart.Writer, body io.Reader, extraHeaders map[string]string) (*http.Response, error) {
+	err := writer.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error closing writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	httpresp.SetExtraHeaders(req, extraHeaders)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	client := &http.Client{}
+	return client.Do(req)
+}
@@ -0,0 +1,59 @@
+package images_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/images"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func TestEdit_MultipleImages(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.png")
+	second := filepath.Join(dir, "second.png")
+	require.NoError(t, os.WriteFile(first, []byte("\x89PNG\r\n\x1a\nfirst"), 0o600))
+	require.NoError(t, os.WriteFile(second, []byte("\x89PNG\r\n\x1a\nsecond"), 0o600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseMultipartForm(10<<20))
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "YourAppName", r.Header.Get("X-App-Name"))
+		assert.Equal(t, "gpt-image-1", r.FormValue("model"))
+		assert.Equal(t, "high", r.FormValue("quality"))
+		assert.Empty(t, r.FormValue("size"))
+		assert.Len(t, r.MultipartForm.File["image[]"], 2)
+		assert.Empty(t, r.MultipartForm.File["mask"])
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := images.Edit(context.Background(), server.URL, "test-token", request.ImageEdit{
+		Model:   "gpt-image-1",
+		Prompt:  "combine",
+		Images:  []string{first, second},
+		Quality: "high",
+	}, map[string]string{"X-App-Name": "YourAppName"})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestEdit_Errors(t *testing.T) {
+	_, err := images.Edit(context.Background(), "http://localhost", "token", request.ImageEdit{Prompt: "x"}, nil)
+	assert.ErrorContains(t, err, "at least one image is required")
+
+	_, err = images.Edit(context.Background(), "http://localhost", "token", request.ImageEdit{Prompt: "x", Images: []string{"missing.png"}}, nil)
+	assert.ErrorContains(t, err, "error opening file")
+
+	_, err = images.Variation(context.Background(), "http://localhost", "token", request.ImageVariation{}, nil)
+	assert.ErrorContains(t, err, "image is required")
+}
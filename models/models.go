@@ -2,6 +2,17 @@ package models
 
 type ModelID string
 
+// ModelMeta.Mode values reported by LiteLLM
+const (
+	MODE_CHAT                = "chat"
+	MODE_EMBEDDING           = "embedding"
+	MODE_IMAGE_GENERATION    = "image_generation"
+	MODE_AUDIO_TRANSCRIPTION = "audio_transcription"
+	MODE_AUDIO_SPEECH        = "audio_speech"
+	MODE_RERANK              = "rerank"
+	MODE_MODERATION          = "moderation"
+)
+
 type Model struct {
 	ID      ModelID `json:"id"`
 	Object  string  `json:"object"`
@@ -0,0 +1,516 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+const (
+	ANTHROPIC_BLOCK_TEXT        = "text"
+	ANTHROPIC_BLOCK_IMAGE       = "image"
+	ANTHROPIC_BLOCK_DOCUMENT    = "document"
+	ANTHROPIC_BLOCK_TOOL_USE    = "tool_use"
+	ANTHROPIC_BLOCK_TOOL_RESULT = "tool_result"
+
+	ANTHROPIC_SOURCE_BASE64 = "base64"
+	ANTHROPIC_SOURCE_URL    = "url"
+	ANTHROPIC_SOURCE_TEXT   = "text"
+	ANTHROPIC_SOURCE_FILE   = "file"
+
+	// ANTHROPIC_MAX_TOKENS is used when MaxTokens is not set, the api requires it.
+	ANTHROPIC_MAX_TOKENS = 4096
+)
+
+// AnthropicMessages is the Anthropic-native /v1/messages request body.
+// https://docs.anthropic.com/en/api/messages
+type AnthropicMessages struct {
+	Model     models.ModelID `json:"model"`
+	MaxTokens int            `json:"max_tokens"`
+	// System is a list of blocks, so each one can carry its own cache control.
+	System        []AnthropicContent   `json:"system,omitempty"`
+	Messages      []AnthropicMessage   `json:"messages"`
+	Tools         []AnthropicTool      `json:"tools,omitempty"`
+	ToolChoice    *AnthropicToolChoice `json:"tool_choice,omitempty"`
+	Thinking      *AnthropicThinking   `json:"thinking,omitempty"`
+	Temperature   *float32             `json:"temperature,omitempty"`
+	TopP          *float32             `json:"top_p,omitempty"`
+	TopK          *int                 `json:"top_k,omitempty"`
+	StopSequences []string             `json:"stop_sequences,omitempty"`
+	Metadata      *AnthropicMetadata   `json:"metadata,omitempty"`
+	Stream        bool                 `json:"stream,omitempty"`
+	// Betas are sent as the "anthropic-beta" header, e.g. "files-api-2025-04-14".
+	Betas []string `json:"-"`
+}
+
+type AnthropicMessage struct {
+	Role    MessageRole        `json:"role"`
+	Content []AnthropicContent `json:"content"`
+}
+
+// AnthropicContent is a request content block. Which fields are used depends on Type.
+type AnthropicContent struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	// Source, Title, Context and Citations are used by image and document blocks.
+	Source    *AnthropicSource    `json:"source,omitempty"`
+	Title     string              `json:"title,omitempty"`
+	Context   string              `json:"context,omitempty"`
+	Citations *AnthropicCitations `json:"citations,omitempty"`
+	// ID, Name and Input are used by tool_use blocks, ToolUseID, Content and IsError by tool_result blocks.
+	ID        string             `json:"id,omitempty"`
+	Name      string             `json:"name,omitempty"`
+	Input     json.RawMessage    `json:"input,omitempty"`
+	ToolUseID string             `json:"tool_use_id,omitempty"`
+	Content   []AnthropicContent `json:"content,omitempty"`
+	IsError   bool               `json:"is_error,omitempty"`
+	// Thinking, Signature and Data replay thinking and redacted_thinking blocks.
+	Thinking     string        `json:"thinking,omitempty"`
+	Signature    string        `json:"signature,omitempty"`
+	Data         string        `json:"data,omitempty"`
+	CacheControl *CacheControl `json:"cache_control,omitempty"`
+}
+
+// AnthropicSource is base64 data, a url, plain text or an uploaded file id.
+type AnthropicSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+	FileID    string `json:"file_id,omitempty"`
+}
+
+type AnthropicCitations struct {
+	Enabled bool `json:"enabled"`
+}
+
+// AnthropicTool is a client tool with InputSchema, or a server tool with Type, e.g. "web_search_20250305".
+type AnthropicTool struct {
+	Type         string                         `json:"type,omitempty"`
+	Name         string                         `json:"name"`
+	Description  string                         `json:"description,omitempty"`
+	InputSchema  *LLMCallToolFunctionParameters `json:"input_schema,omitempty"`
+	CacheControl *CacheControl                  `json:"cache_control,omitempty"`
+}
+
+// AnthropicToolChoice Type is "auto", "any", "tool" with Name, or "none".
+type AnthropicToolChoice struct {
+	Type                   string `json:"type"`
+	Name                   string `json:"name,omitempty"`
+	DisableParallelToolUse bool   `json:"disable_parallel_tool_use,omitempty"`
+}
+
+// AnthropicThinking enables extended thinking, BudgetTokens must be less than MaxTokens.
+type AnthropicThinking struct {
+	Type         string `json:"type"`
+	BudgetTokens int    `json:"budget_tokens"`
+}
+
+type AnthropicMetadata struct {
+	UserID string `json:"user_id,omitempty"`
+}
+
+// AnthropicText returns a text block.
+func AnthropicText(text string) AnthropicContent {
+	return AnthropicContent{Type: ANTHROPIC_BLOCK_TEXT, Text: text}
+}
+
+// AnthropicDocument returns a document block, e.g. a base64 pdf or a plain text source.
+func AnthropicDocument(source AnthropicSource, title string, citations bool) AnthropicContent {
+	block := AnthropicContent{Type: ANTHROPIC_BLOCK_DOCUMENT, Source: &source, Title: title}
+	if citations {
+		block.Citations = &AnthropicCitations{Enabled: true}
+	}
+	return block
+}
+
+// ThinkingBudget enables extended thinking with the given token budget.
+func ThinkingBudget(tokens int) *AnthropicThinking {
+	return &AnthropicThinking{Type: "enabled", BudgetTokens: tokens}
+}
+
+func (c AnthropicContent) Cache(controlType CacheControlType, options ...CacheOption) AnthropicContent {
+	cacheControl := CacheControl{
+		Type: controlType,
+	}
+
+	for _, option := range options {
+		option(&cacheControl)
+	}
+
+	c.CacheControl = &cacheControl
+	return c
+}
+
+// WithDefaults sets MaxTokens when it is missing or does not leave room above the thinking budget.
+func (r AnthropicMessages) WithDefaults() AnthropicMessages {
+	if r.MaxTokens == 0 {
+		r.MaxTokens = ANTHROPIC_MAX_TOKENS
+	}
+	if r.Thinking != nil && r.MaxTokens <= r.Thinking.BudgetTokens {
+		r.MaxTokens = r.Thinking.BudgetTokens + ANTHROPIC_MAX_TOKENS
+	}
+	return r
+}
+
+// ValidateToolChoice checks ToolChoice against Tools, so typos fail before the request is sent.
+func (r AnthropicMessages) ValidateToolChoice() error {
+	if r.ToolChoice == nil {
+		return nil
+	}
+	switch r.ToolChoice.Type {
+	case string(TOOL_CHOICE_AUTO), string(TOOL_CHOICE_NONE):
+		return nil
+	case "any":
+		if len(r.Tools) == 0 {
+			return fmt.Errorf("tool_choice %q requires request tools", r.ToolChoice.Type)
+		}
+		return nil
+	case "tool":
+		if r.ToolChoice.Name == "" {
+			return fmt.Errorf("tool_choice tool name cannot be empty")
+		}
+		for _, tool := range r.Tools {
+			if tool.Name == r.ToolChoice.Name {
+				return nil
+			}
+		}
+		return fmt.Errorf("tool_choice tool %q is not in request tools", r.ToolChoice.Name)
+	}
+	return fmt.Errorf("unknown tool_choice %q", r.ToolChoice.Type)
+}
+
+// ToAnthropic converts a chat completion request. Messages, tools, tool choice,
+// temperature, top p, max tokens, stop sequences and user are carried over.
+func (r *Request) ToAnthropic() (*AnthropicMessages, error) {
+	system, messages, err := ToAnthropicMessages(r.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &AnthropicMessages{
+		Model:         r.Model,
+		MaxTokens:     max(r.MaxCompletionTokens, r.MaxTokens),
+		System:        system,
+		Messages:      messages,
+		TopP:          r.TopP,
+		StopSequences: r.Stop,
+		Stream:        r.Stream,
+	}
+	if r.Temperature != 0 {
+		temperature := r.Temperature
+		res.Temperature = &temperature
+	}
+	if r.User != "" {
+		res.Metadata = &AnthropicMetadata{UserID: r.User}
+	}
+	if r.Tools != nil {
+		res.Tools = AnthropicTools(*r.Tools)
+	}
+	if r.ToolChoice != nil {
+		choice := &AnthropicToolChoice{Type: string(r.ToolChoice.Mode)}
+		if r.ToolChoice.Function != nil {
+			choice = &AnthropicToolChoice{Type: "tool", Name: r.ToolChoice.Function.Name}
+		} else if r.ToolChoice.Mode == TOOL_CHOICE_REQUIRED {
+			choice.Type = "any"
+		}
+		res.ToolChoice = choice
+	}
+	if r.ParallelToolCalls != nil && !*r.ParallelToolCalls {
+		if res.ToolChoice == nil {
+			res.ToolChoice = &AnthropicToolChoice{Type: string(TOOL_CHOICE_AUTO)}
+		}
+		res.ToolChoice.DisableParallelToolUse = true
+	}
+	return res, nil
+}
+
+// AnthropicTools converts chat function tools. Tools without parameters get an empty object schema.
+func AnthropicTools(tools LLMCallTools) []AnthropicTool {
+	converted := make([]AnthropicTool, 0, len(tools))
+	for _, tool := range tools {
+		if tool.Function == nil {
+			continue
+		}
+		schema := tool.Function.Parameters
+		if schema == nil {
+			schema = &LLMCallToolFunctionParameters{Type: "object"}
+		}
+		converted = append(converted, AnthropicTool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			InputSchema: schema,
+		})
+	}
+	return converted
+}
+
+// ToAnthropicMessages converts chat messages. System messages become system blocks, tool messages
+// become tool_result blocks and consecutive messages of the same role are merged, as the api requires.
+// Cache control, thinking blocks and tool calls are kept, audio parts are not supported.
+func ToAnthropicMessages(messages Messages) ([]AnthropicContent, []AnthropicMessage, error) {
+	var system []AnthropicContent
+	converted := make([]AnthropicMessage, 0, len(messages))
+	add := func(role MessageRole, blocks ...AnthropicContent) {
+		if last := len(converted) - 1; last >= 0 && converted[last].Role == role {
+			converted[last].Content = append(converted[last].Content, blocks...)
+			return
+		}
+		converted = append(converted, AnthropicMessage{Role: role, Content: blocks})
+	}
+
+	for i, message := range messages {
+		switch message.Role {
+		case ROLE_SYSTEM:
+			for _, part := range message.Contents {
+				if part.Type != "text" {
+					return nil, nil, fmt.Errorf("message %d: system content type %q is not supported", i, part.Type)
+				}
+				system = append(system, AnthropicContent{Type: ANTHROPIC_BLOCK_TEXT, Text: part.Text, CacheControl: part.CacheControl})
+			}
+		case ROLE_TOOL:
+			// the api rejects empty text blocks
+			text := message.Contents.String()
+			if text == "" {
+				text = "-"
+			}
+			add(ROLE_USER, AnthropicContent{
+				Type:      ANTHROPIC_BLOCK_TOOL_RESULT,
+				ToolUseID: message.ToolCallID,
+				Content:   []AnthropicContent{AnthropicText(text)},
+			})
+		case ROLE_ASSISTANT:
+			blocks := make([]AnthropicContent, 0, len(message.ThinkingBlocks)+len(message.Contents)+len(message.ToolCalls))
+			for _, thinking := range message.ThinkingBlocks {
+				blocks = append(blocks, AnthropicContent{
+					Type:      thinking.Type,
+					Thinking:  thinking.Thinking,
+					Signature: thinking.Signature,
+					Data:      thinking.Data,
+				})
+			}
+			for _, part := range assistantTexts(message) {
+				blocks = append(blocks, AnthropicContent{Type: ANTHROPIC_BLOCK_TEXT, Text: part.Text, CacheControl: part.CacheControl})
+			}
+			for _, toolCall := range message.ToolCalls {
+				input, err := toolCallArguments(toolCall)
+				if err != nil {
+					return nil, nil, fmt.Errorf("message %d: %w", i, err)
+				}
+				blocks = append(blocks, AnthropicContent{Type: ANTHROPIC_BLOCK_TOOL_USE, ID: toolCall.ID, Name: toolCall.Function.Name, Input: input})
+			}
+			// the api rejects messages without content, an empty assistant turn adds nothing
+			if len(blocks) == 0 {
+				continue
+			}
+			add(ROLE_ASSISTANT, blocks...)
+		default:
+			blocks := make([]AnthropicContent, 0, len(message.Contents))
+			for _, part := range message.Contents {
+				block, err := anthropicBlock(part)
+				if err != nil {
+					return nil, nil, fmt.Errorf("message %d: %w", i, err)
+				}
+				blocks = append(blocks, block)
+			}
+			add(message.Role, blocks...)
+		}
+	}
+	return system, converted, nil
+}
+
+func anthropicBlock(part MessageContent) (AnthropicContent, error) {
+	block := AnthropicContent{CacheControl: part.CacheControl}
+	switch {
+	case part.Type == "text":
+		block.Type = ANTHROPIC_BLOCK_TEXT
+		block.Text = part.Text
+	case part.Type == "image_url" && part.ImageUrl != nil:
+		block.Type = ANTHROPIC_BLOCK_IMAGE
+		block.Source = anthropicSource(part.ImageUrl.URL)
+	case part.Type == "file" && part.File != nil:
+		block.Type = ANTHROPIC_BLOCK_DOCUMENT
+		block.Title = part.File.Filename
+		if part.File.FileID != "" {
+			block.Source = &AnthropicSource{Type: ANTHROPIC_SOURCE_FILE, FileID: part.File.FileID}
+		} else {
+			block.Source = anthropicSource(part.File.FileData)
+		}
+	default:
+		return AnthropicContent{}, fmt.Errorf("content type %q is not supported by the anthropic api", part.Type)
+	}
+	return block, nil
+}
+
+// anthropicSource splits a base64 data URL, other values are sent as url sources.
+func anthropicSource(url string) *AnthropicSource {
+	if meta, data, ok := strings.Cut(strings.TrimPrefix(url, "data:"), ";base64,"); ok && strings.HasPrefix(url, "data:") {
+		return &AnthropicSource{Type: ANTHROPIC_SOURCE_BASE64, MediaType: meta, Data: data}
+	}
+	return &AnthropicSource{Type: ANTHROPIC_SOURCE_URL, URL: url}
+}
+
+// FromAnthropicMessages converts Anthropic messages back to chat messages. tool_result blocks
+// become tool messages and tool_use blocks tool calls. Citations and is_error are not carried over.
+func FromAnthropicMessages(system []AnthropicContent, messages []AnthropicMessage) (Messages, error) {
+	converted := make(Messages, 0, len(messages)+1)
+	if len(system) > 0 {
+		contents := make(MessageContents, 0, len(system))
+		for _, block := range system {
+			if block.Type != ANTHROPIC_BLOCK_TEXT {
+				return nil, fmt.Errorf("system block type %q is not supported", block.Type)
+			}
+			contents = append(contents, MessageContent{Type: "text", Text: block.Text, CacheControl: block.CacheControl})
+		}
+		converted = append(converted, SystemMessage(contents))
+	}
+
+	for i, message := range messages {
+		if message.Role == ROLE_ASSISTANT {
+			assistant, err := fromAnthropicAssistant(message.Content)
+			if err != nil {
+				return nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			converted = append(converted, assistant)
+			continue
+		}
+
+		contents := make(MessageContents, 0, len(message.Content))
+		for _, block := range message.Content {
+			if block.Type != ANTHROPIC_BLOCK_TOOL_RESULT {
+				content, err := fromAnthropicBlock(block)
+				if err != nil {
+					return nil, fmt.Errorf("message %d: %w", i, err)
+				}
+				contents = append(contents, content)
+				continue
+			}
+
+			// keep the block order, text before a tool result stays before it
+			if len(contents) > 0 {
+				converted = append(converted, Message{Role: message.Role, Contents: contents})
+				contents = make(MessageContents, 0)
+			}
+			texts := make([]string, 0, len(block.Content))
+			for _, result := range block.Content {
+				if result.Type != ANTHROPIC_BLOCK_TEXT {
+					return nil, fmt.Errorf("message %d: tool_result block type %q is not supported", i, result.Type)
+				}
+				texts = append(texts, result.Text)
+			}
+			text := strings.Join(texts, "\n")
+			if text == "" {
+				text = "-"
+			}
+			converted = append(converted, Message{
+				Role:       ROLE_TOOL,
+				ToolCallID: block.ToolUseID,
+				Contents:   MessageContents{{Type: "text", Text: text, CacheControl: block.CacheControl}},
+			})
+		}
+		if len(contents) > 0 {
+			converted = append(converted, Message{Role: message.Role, Contents: contents})
+		}
+	}
+	return converted, nil
+}
+
+func fromAnthropicAssistant(blocks []AnthropicContent) (Message, error) {
+	message := Message{Role: ROLE_ASSISTANT}
+	for _, block := range blocks {
+		switch block.Type {
+		case ANTHROPIC_BLOCK_TEXT:
+			message.Contents = append(message.Contents, MessageContent{Type: "text", Text: block.Text, CacheControl: block.CacheControl})
+		case ANTHROPIC_BLOCK_TOOL_USE:
+			args := make(common.Arguments)
+			if len(block.Input) > 0 {
+				if err := args.UnmarshalJSON(block.Input); err != nil {
+					return Message{}, fmt.Errorf("tool use %s: %w", block.ID, err)
+				}
+			}
+			message.ToolCalls = append(message.ToolCalls, common.ToolCall{
+				ID:       block.ID,
+				Type:     FunctionToolType,
+				Index:    len(message.ToolCalls),
+				Function: common.ToolCallFunction{Name: block.Name, Arguments: args},
+			})
+		case common.THINKING_BLOCK_THINKING, common.THINKING_BLOCK_REDACTED:
+			message.ThinkingBlocks = append(message.ThinkingBlocks, common.ThinkingBlock{
+				Type:      block.Type,
+				Thinking:  block.Thinking,
+				Signature: block.Signature,
+				Data:      block.Data,
+			})
+		default:
+			return Message{}, fmt.Errorf("assistant block type %q is not supported", block.Type)
+		}
+	}
+	if len(message.Contents) == 0 {
+		// important, content text must be present
+		message.Contents = MessageContents{{Type: "text", Text: "-"}}
+	}
+	return message, nil
+}
+
+func fromAnthropicBlock(block AnthropicContent) (MessageContent, error) {
+	content := MessageContent{Type: "text", CacheControl: block.CacheControl}
+	switch block.Type {
+	case ANTHROPIC_BLOCK_TEXT:
+		content.Text = block.Text
+		return content, nil
+	case ANTHROPIC_BLOCK_IMAGE, ANTHROPIC_BLOCK_DOCUMENT:
+		if block.Source == nil {
+			return MessageContent{}, fmt.Errorf("%s block without source", block.Type)
+		}
+	default:
+		return MessageContent{}, fmt.Errorf("block type %q is not supported", block.Type)
+	}
+
+	source := block.Source
+	url := source.URL
+	if source.Type == ANTHROPIC_SOURCE_BASE64 {
+		url = fmt.Sprintf("data:%s;base64,%s", source.MediaType, source.Data)
+	}
+	switch {
+	case block.Type == ANTHROPIC_BLOCK_IMAGE && (source.Type == ANTHROPIC_SOURCE_BASE64 || source.Type == ANTHROPIC_SOURCE_URL):
+		content.Type = "image_url"
+		content.ImageUrl = &ImageUrl{URL: url, Format: source.MediaType}
+	case source.Type == ANTHROPIC_SOURCE_TEXT:
+		content.Text = source.Data
+	case source.Type == ANTHROPIC_SOURCE_FILE:
+		content.Type = "file"
+		content.File = &File{FileID: source.FileID, Filename: block.Title}
+	case source.Type == ANTHROPIC_SOURCE_BASE64 || source.Type == ANTHROPIC_SOURCE_URL:
+		content.Type = "file"
+		content.File = &File{FileData: url, Filename: block.Title, Format: source.MediaType}
+	default:
+		return MessageContent{}, fmt.Errorf("%s source type %q is not supported", block.Type, source.Type)
+	}
+	return content, nil
+}
+
+// AnthropicAssistantMessage turns a response into the assistant message of the next request.
+// Text, tool use and thinking blocks are kept with their signatures, citations are not replayed.
+func AnthropicAssistantMessage(res response.AnthropicResponse) AnthropicMessage {
+	blocks := make([]AnthropicContent, 0, len(res.Content))
+	for _, block := range res.Content {
+		switch block.Type {
+		case response.ANTHROPIC_BLOCK_TEXT, response.ANTHROPIC_BLOCK_TOOL_USE, common.THINKING_BLOCK_THINKING, common.THINKING_BLOCK_REDACTED:
+			blocks = append(blocks, AnthropicContent{
+				Type:      block.Type,
+				Text:      block.Text,
+				ID:        block.ID,
+				Name:      block.Name,
+				Input:     block.Input,
+				Thinking:  block.Thinking,
+				Signature: block.Signature,
+				Data:      block.Data,
+			})
+		}
+	}
+	return AnthropicMessage{Role: ROLE_ASSISTANT, Content: blocks}
+}
@@ -0,0 +1,157 @@
+package request_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func TestToAnthropicMessages(t *testing.T) {
+	toolCall := common.ToolCall{
+		ID:       "toolu-1",
+		Type:     "function",
+		Function: common.ToolCallFunction{Name: "get_weather", Arguments: common.Arguments{"city": "Riga"}},
+	}
+	messages := request.Messages{
+		request.SystemMessageSimple("be brief").CachePoint(),
+		request.UserMessageFile("summarize", request.File{FileData: "data:application/pdf;base64,JVBER", Filename: "report.pdf"}),
+		request.AIMessage(response.ResponseMessage{
+			Role:           "assistant",
+			ToolCalls:      common.ToolCalls{toolCall},
+			ThinkingBlocks: common.ThinkingBlocks{{Type: common.THINKING_BLOCK_THINKING, Thinking: "use tool", Signature: "sig-1"}},
+		}),
+		request.ToolCallMessage(toolCall, response.ToolResponse{Text: "sunny"}),
+		request.UserMessageImage("and this?", request.MessageImage("https://example.com/a.png")),
+	}
+
+	system, converted, err := request.ToAnthropicMessages(messages)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(request.AnthropicMessages{Model: "claude-sonnet-4", System: system, Messages: converted}.WithDefaults())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"model":"claude-sonnet-4",
+		"max_tokens":4096,
+		"system":[{"type":"text","text":"be brief","cache_control":{"type":"ephemeral"}}],
+		"messages":[
+			{"role":"user","content":[
+				{"type":"text","text":"summarize"},
+				{"type":"document","title":"report.pdf","source":{"type":"base64","media_type":"application/pdf","data":"JVBER"}}
+			]},
+			{"role":"assistant","content":[
+				{"type":"thinking","thinking":"use tool","signature":"sig-1"},
+				{"type":"tool_use","id":"toolu-1","name":"get_weather","input":{"city":"Riga"}}
+			]},
+			{"role":"user","content":[
+				{"type":"tool_result","tool_use_id":"toolu-1","content":[{"type":"text","text":"sunny"}]},
+				{"type":"text","text":"and this?"},
+				{"type":"image","source":{"type":"url","url":"https://example.com/a.png"}}
+			]}
+		]
+	}`, string(data))
+
+	back, err := request.FromAnthropicMessages(system, converted)
+	require.NoError(t, err)
+	require.Len(t, back, 5)
+	assert.Equal(t, messages[0], back[0])
+	assert.Equal(t, request.ROLE_ASSISTANT, back[2].Role)
+	assert.Equal(t, "-", back[2].Contents.String())
+	assert.Equal(t, common.ToolCalls{toolCall}, back[2].ToolCalls)
+	assert.Equal(t, messages[2].ThinkingBlocks, back[2].ThinkingBlocks)
+	assert.Equal(t, request.ROLE_TOOL, back[3].Role)
+	assert.Equal(t, "toolu-1", back[3].ToolCallID)
+	assert.Equal(t, "sunny", back[3].Contents.String())
+	assert.Equal(t, "and this? [Image: https://example.com/a.png]", back[4].Contents.String())
+	assert.Equal(t, "data:application/pdf;base64,JVBER", back[1].Contents[1].File.FileData)
+
+	// empty tool results still need text, urls of documents come back as file data
+	empty := request.Message{Role: request.ROLE_TOOL, ToolCallID: "toolu-1"}
+	_, converted, err = request.ToAnthropicMessages(request.Messages{empty})
+	require.NoError(t, err)
+	assert.Equal(t, "-", converted[0].Content[0].Content[0].Text)
+
+	// empty assistant messages are skipped, the user turns around them are merged
+	blank := request.Message{Role: request.ROLE_ASSISTANT, Contents: request.MessageContents{{Type: "text", Text: ""}}}
+	_, converted, err = request.ToAnthropicMessages(request.Messages{request.UserMessageSimple("hi"), blank, request.UserMessageSimple("there")})
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	assert.Len(t, converted[0].Content, 2)
+
+	document := request.AnthropicDocument(request.AnthropicSource{Type: request.ANTHROPIC_SOURCE_URL, URL: "https://example.com/a.pdf"}, "a.pdf", false)
+	back, err = request.FromAnthropicMessages(nil, []request.AnthropicMessage{{Role: request.ROLE_USER, Content: []request.AnthropicContent{document}}})
+	require.NoError(t, err)
+	assert.Equal(t, &request.File{FileData: "https://example.com/a.pdf", Filename: "a.pdf"}, back[0].Contents[0].File)
+
+	_, _, err = request.ToAnthropicMessages(request.Messages{request.UserMessageAudio("", request.InputAudio{Data: "AAAA", Format: "wav"})})
+	assert.EqualError(t, err, `message 0: content type "input_audio" is not supported by the anthropic api`)
+}
+
+func TestRequest_ToAnthropic(t *testing.T) {
+	tools := request.LLMCallTools{{Type: request.FunctionToolType, Function: &request.LLMCallToolFunction{Name: "now"}}}
+	parallel := false
+	req := &request.Request{
+		Model:             "claude-sonnet-4",
+		Messages:          request.Messages{request.UserMessageSimple("time?")},
+		Tools:             &tools,
+		ToolChoice:        &request.ToolChoice{Mode: request.TOOL_CHOICE_REQUIRED},
+		ParallelToolCalls: &parallel,
+		MaxTokens:         200,
+		Stop:              []string{"END"},
+		User:              "user-1",
+	}
+
+	res, err := req.ToAnthropic()
+	require.NoError(t, err)
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"model":"claude-sonnet-4",
+		"max_tokens":200,
+		"messages":[{"role":"user","content":[{"type":"text","text":"time?"}]}],
+		"tools":[{"name":"now","input_schema":{"type":"object"}}],
+		"tool_choice":{"type":"any","disable_parallel_tool_use":true},
+		"stop_sequences":["END"],
+		"metadata":{"user_id":"user-1"}
+	}`, string(data))
+}
+
+func TestAnthropicAssistantMessage(t *testing.T) {
+	res := response.AnthropicResponse{Content: []response.AnthropicContent{
+		{Type: "redacted_thinking", Data: "enc"},
+		{Type: "text", Text: "Blue.", Citations: []response.AnthropicCitation{{CitedText: "The sky is blue."}}},
+		{Type: "server_tool_use", ID: "srvtoolu-1"},
+		{Type: "tool_use", ID: "toolu-1", Name: "now", Input: json.RawMessage(`{}`)},
+	}}
+
+	data, err := json.Marshal(request.AnthropicAssistantMessage(res))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"role":"assistant","content":[
+		{"type":"redacted_thinking","data":"enc"},
+		{"type":"text","text":"Blue."},
+		{"type":"tool_use","id":"toolu-1","name":"now","input":{}}
+	]}`, string(data))
+}
+
+func TestAnthropicMessages_ValidateToolChoice(t *testing.T) {
+	req := request.AnthropicMessages{Tools: []request.AnthropicTool{{Name: "get_weather"}}}
+	assert.NoError(t, req.ValidateToolChoice())
+
+	req.ToolChoice = &request.AnthropicToolChoice{Type: "tool", Name: "get_weather"}
+	assert.NoError(t, req.ValidateToolChoice())
+	req.ToolChoice.Name = "get_wether"
+	assert.EqualError(t, req.ValidateToolChoice(), `tool_choice tool "get_wether" is not in request tools`)
+
+	req.ToolChoice = &request.AnthropicToolChoice{Type: "any"}
+	assert.NoError(t, req.ValidateToolChoice())
+	req.Tools = nil
+	assert.EqualError(t, req.ValidateToolChoice(), `tool_choice "any" requires request tools`)
+
+	req.ToolChoice = &request.AnthropicToolChoice{Type: "required"}
+	assert.EqualError(t, req.ValidateToolChoice(), `unknown tool_choice "required"`)
+}
@@ -0,0 +1,32 @@
+package request
+
+const (
+	FILE_PURPOSE_BATCH = "batch"
+
+	BATCH_ENDPOINT_CHAT       = "/v1/chat/completions"
+	BATCH_ENDPOINT_EMBEDDINGS = "/v1/embeddings"
+
+	BATCH_COMPLETION_WINDOW = "24h"
+)
+
+// Batch is the /v1/batches create request body.
+type Batch struct {
+	// InputFileID is a JSONL file uploaded with purpose FILE_PURPOSE_BATCH, see package batch.
+	InputFileID string `json:"input_file_id"`
+	// Endpoint defaults to BATCH_ENDPOINT_CHAT.
+	Endpoint string `json:"endpoint"`
+	// CompletionWindow defaults to BATCH_COMPLETION_WINDOW, the only window OpenAI supports.
+	CompletionWindow string            `json:"completion_window"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// WithDefaults fills Endpoint and CompletionWindow when empty.
+func (b Batch) WithDefaults() Batch {
+	if b.Endpoint == "" {
+		b.Endpoint = BATCH_ENDPOINT_CHAT
+	}
+	if b.CompletionWindow == "" {
+		b.CompletionWindow = BATCH_COMPLETION_WINDOW
+	}
+	return b
+}
@@ -1,6 +1,111 @@
 package request
 
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	EMBEDDING_ENCODING_FLOAT  = "float"
+	EMBEDDING_ENCODING_BASE64 = "base64"
+)
+
 type EmbeddingRequest struct {
 	Model string `json:"model"`
 	Input string `json:"input"`
+	// Inputs embeds several texts in one call and is sent instead of Input when set.
+	Inputs []string `json:"-"`
+	EmbeddingParams
+}
+
+// EmbeddingParams are the optional parameters of an embeddings call.
+type EmbeddingParams struct {
+	// Dimensions truncates the embedding, supported by text-embedding-3 and newer models.
+	Dimensions int `json:"dimensions,omitempty"`
+	// EncodingFormat is float (default) or base64. base64 payloads are decoded by response.Embedding.
+	EncodingFormat string `json:"encoding_format,omitempty"`
+	// InputType is passed to providers that embed queries and documents differently, e.g. "search_query".
+	InputType string `json:"input_type,omitempty"`
+	User      string `json:"user,omitempty"`
+}
+
+func (p EmbeddingParams) Validate() error {
+	if p.Dimensions < 0 {
+		return fmt.Errorf("dimensions must not be negative")
+	}
+	switch p.EncodingFormat {
+	case "", EMBEDDING_ENCODING_FLOAT, EMBEDDING_ENCODING_BASE64:
+		return nil
+	}
+	return fmt.Errorf("unsupported encoding_format %q", p.EncodingFormat)
+}
+
+func (r EmbeddingRequest) MarshalJSON() ([]byte, error) {
+	type alias EmbeddingRequest
+	if len(r.Inputs) == 0 {
+		return json.Marshal(alias(r))
+	}
+	return json.Marshal(struct {
+		alias
+		Input []string `json:"input"`
+	}{
+		alias: alias(r),
+		Input: r.Inputs,
+	})
+}
+
+const (
+	DefaultEmbeddingBatchSize   = 2048
+	DefaultEmbeddingBatchTokens = 100_000
+)
+
+// EmbeddingBatchOptions limits the requests a batch of inputs is split into.
+type EmbeddingBatchOptions struct {
+	// MaxInputs per request. Defaults to DefaultEmbeddingBatchSize.
+	MaxInputs int
+	// MaxTokens per request, estimated with EstimateTokens. Defaults to DefaultEmbeddingBatchTokens.
+	MaxTokens int
+	// Concurrency limits parallel requests. Defaults to 4.
+	Concurrency int
+	// Params are sent with every request.
+	Params EmbeddingParams
+}
+
+func (o EmbeddingBatchOptions) withDefaults() EmbeddingBatchOptions {
+	if o.MaxInputs <= 0 {
+		o.MaxInputs = DefaultEmbeddingBatchSize
+	}
+	if o.MaxTokens <= 0 {
+		o.MaxTokens = DefaultEmbeddingBatchTokens
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	return o
+}
+
+// Batches splits inputs into consecutive [start, end) ranges within the limits.
+// An input over MaxTokens gets a batch of its own.
+func (o EmbeddingBatchOptions) Batches(inputs []string) [][2]int {
+	o = o.withDefaults()
+	batches := make([][2]int, 0, len(inputs)/o.MaxInputs+1)
+	start, tokens := 0, 0
+	for i, input := range inputs {
+		inputTokens := EstimateTokens(input)
+		if i > start && (i-start >= o.MaxInputs || tokens+inputTokens > o.MaxTokens) {
+			batches = append(batches, [2]int{start, i})
+			start, tokens = i, 0
+		}
+		tokens += inputTokens
+	}
+	if start < len(inputs) {
+		batches = append(batches, [2]int{start, len(inputs)})
+	}
+	return batches
+}
+
+// EstimateTokens roughly estimates the token count of text as one token per 4 characters.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
 }
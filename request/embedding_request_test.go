@@ -0,0 +1,63 @@
+package request_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func TestEmbeddingRequest_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(request.EmbeddingRequest{Model: "m", Input: "one"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"m","input":"one"}`, string(data))
+
+	data, err = json.Marshal(request.EmbeddingRequest{Model: "m", Input: "ignored", Inputs: []string{"one", "two"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"m","input":["one","two"]}`, string(data))
+}
+
+func TestEmbeddingBatchOptions_Batches(t *testing.T) {
+	inputs := []string{"a", "b", "c", "d", "e"}
+
+	assert.Equal(t, [][2]int{{0, 5}}, request.EmbeddingBatchOptions{}.Batches(inputs))
+	assert.Equal(t, [][2]int{{0, 2}, {2, 4}, {4, 5}}, request.EmbeddingBatchOptions{MaxInputs: 2}.Batches(inputs))
+	assert.Empty(t, request.EmbeddingBatchOptions{}.Batches(nil))
+
+	// 40 characters are estimated as 10 tokens
+	long := strings.Repeat("x", 40)
+	byTokens := request.EmbeddingBatchOptions{MaxTokens: 11}.Batches([]string{"a", "b", long, "c", long, long})
+	assert.Equal(t, [][2]int{{0, 2}, {2, 4}, {4, 5}, {5, 6}}, byTokens)
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, request.EstimateTokens(""))
+	assert.Equal(t, 1, request.EstimateTokens("abc"))
+	assert.Equal(t, 2, request.EstimateTokens("hello"))
+	assert.Equal(t, 1, request.EstimateTokens("äöüß"))
+}
+
+func TestEmbeddingRequest_Params(t *testing.T) {
+	req := request.EmbeddingRequest{
+		Model:  "text-embedding-3-small",
+		Inputs: []string{"query"},
+		EmbeddingParams: request.EmbeddingParams{
+			Dimensions:     256,
+			EncodingFormat: request.EMBEDDING_ENCODING_BASE64,
+			InputType:      "search_query",
+			User:           "user-1",
+		},
+	}
+	require.NoError(t, req.EmbeddingParams.Validate())
+
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"text-embedding-3-small","input":["query"],"dimensions":256,"encoding_format":"base64","input_type":"search_query","user":"user-1"}`, string(data))
+
+	assert.ErrorContains(t, request.EmbeddingParams{EncodingFormat: "binary"}.Validate(), `unsupported encoding_format "binary"`)
+	assert.ErrorContains(t, request.EmbeddingParams{Dimensions: -1}.Validate(), "dimensions must not be negative")
+}
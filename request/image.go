@@ -0,0 +1,54 @@
+package request
+
+import "github.com/andrejsstepanovs/go-litellm/models"
+
+const (
+	IMAGE_RESPONSE_FORMAT_URL = "url"
+	IMAGE_RESPONSE_FORMAT_B64 = "b64_json"
+)
+
+// ImageGeneration is the /images/generations request body.
+type ImageGeneration struct {
+	Model  models.ModelID `json:"model"`
+	Prompt string         `json:"prompt"`
+
+	// Optional e.g. "1024x1024", "1536x1024", "auto"
+	Size string `json:"size,omitempty"`
+	// Optional e.g. "standard", "hd", "low", "medium", "high"
+	Quality string `json:"quality,omitempty"`
+	// Optional number of images, defaults to 1
+	N int `json:"n,omitempty"`
+	// Optional "url" or "b64_json"
+	ResponseFormat string `json:"response_format,omitempty"`
+	// Optional "vivid" or "natural" (dall-e-3)
+	Style string `json:"style,omitempty"`
+	User  string `json:"user,omitempty"`
+}
+
+// ImageEdit is the multipart /images/edits request.
+type ImageEdit struct {
+	Model  models.ModelID
+	Prompt string
+	// Images are local file paths. Models that accept several images receive them as image[].
+	Images []string
+	// Optional local path to a PNG mask; transparent areas are edited.
+	Mask string
+
+	Size           string
+	Quality        string
+	N              int
+	ResponseFormat string
+	User           string
+}
+
+// ImageVariation is the multipart /images/variations request.
+type ImageVariation struct {
+	Model models.ModelID
+	// Image is a local file path.
+	Image string
+
+	Size           string
+	N              int
+	ResponseFormat string
+	User           string
+}
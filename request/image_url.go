@@ -4,11 +4,49 @@ package request
 // 	"type": "image_url",
 // 	"image_url": {
 // 		"url": "https://upload.wikimedia.org/wikipedia/commons/thumb/d/dd/Gfp-wisconsin-madison-the-nature-boardwalk.jpg/2560px-Gfp-wisconsin-madison-the-nature-boardwalk.jpg",
-// 		"format": "image/jpeg"
+// 		"format": "image/jpeg",
+// 		"detail": "high"
 // 	}
 // }
 
+const (
+	ImageDetailAuto = "auto"
+	ImageDetailLow  = "low"
+	ImageDetailHigh = "high"
+)
+
 type ImageUrl struct {
 	URL    string `json:"url"`
-	Format string `json:"format"` // Optional field for image format
+	Format string `json:"format"`           // Optional field for image format
+	Detail string `json:"detail,omitempty"` // Optional "auto", "low" or "high"
+}
+
+// {
+// 	"type": "file",
+// 	"file": {
+// 		"file_data": "data:application/pdf;base64,JVBERi0xLjQK...",
+// 		"filename": "report.pdf"
+// 	}
+// }
+
+// File is the LiteLLM "file" content part used for PDFs and other documents.
+type File struct {
+	FileData string `json:"file_data,omitempty"` // base64 data URL
+	FileID   string `json:"file_id,omitempty"`   // previously uploaded file id
+	Filename string `json:"filename,omitempty"`
+	Format   string `json:"format,omitempty"` // Optional mime type, e.g. "application/pdf"
+}
+
+// {
+// 	"type": "input_audio",
+// 	"input_audio": {
+// 		"data": "UklGRiQAAABXQVZFZm10...",
+// 		"format": "wav"
+// 	}
+// }
+
+// InputAudio is the "input_audio" content part. Data is plain base64 (not a data URL).
+type InputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"` // "wav", "mp3", ...
 }
@@ -0,0 +1,22 @@
+package request
+
+// LiteLLMCache controls the LiteLLM proxy response cache for one request.
+// See https://docs.litellm.ai/docs/proxy/caching#dynamic-cache-controls
+type LiteLLMCache struct {
+	// NoCache skips cached responses, the fresh response is still stored.
+	NoCache bool `json:"no-cache,omitempty"`
+	// NoStore does not store the response.
+	NoStore bool `json:"no-store,omitempty"`
+	// TTL in seconds for the stored response.
+	TTL int `json:"ttl,omitempty"`
+	// SMaxAge in seconds accepts only cached responses younger than this.
+	SMaxAge int `json:"s-maxage,omitempty"`
+	// Namespace separates cached responses, e.g. per tenant.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// SetCache sets the LiteLLM cache controls. They are honored by cache.Completions as well.
+func (r *Request) SetCache(cache LiteLLMCache) *Request {
+	r.Cache = &cache
+	return r
+}
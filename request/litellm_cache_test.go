@@ -0,0 +1,24 @@
+package request_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func TestRequest_SetCache(t *testing.T) {
+	req := request.NewRequest(models.ModelMeta{ModelId: "gpt-4o"})
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"cache"`)
+
+	req.SetCache(request.LiteLLMCache{NoCache: true, NoStore: true, TTL: 600, SMaxAge: 60, Namespace: "tenant-1"})
+	data, err = json.Marshal(req)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"cache":{"no-cache":true,"no-store":true,"ttl":600,"s-maxage":60,"namespace":"tenant-1"}`)
+}
@@ -0,0 +1,368 @@
+package request
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // register gif decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultMaxMediaBytes is the default size limit for local media loaded into a message.
+const DefaultMaxMediaBytes int64 = 20 << 20
+
+var ErrMediaTooLarge = errors.New("media exceeds size limit")
+
+type mediaConfig struct {
+	maxBytes     int64
+	mimeType     string
+	filename     string
+	maxDimension int
+	imageFormat  string
+	imageDetail  string
+}
+
+type MediaOption func(*mediaConfig)
+
+// MediaMaxBytes overrides DefaultMaxMediaBytes. Use value <= 0 to disable the limit.
+func MediaMaxBytes(limit int64) MediaOption {
+	return func(c *mediaConfig) {
+		c.maxBytes = limit
+	}
+}
+
+// MediaMIMEType skips content sniffing and uses the given mime type.
+func MediaMIMEType(mimeType string) MediaOption {
+	return func(c *mediaConfig) {
+		c.mimeType = mimeType
+	}
+}
+
+// MediaFilename sets the filename sent with file parts. Path based constructors set it automatically.
+func MediaFilename(name string) MediaOption {
+	return func(c *mediaConfig) {
+		c.filename = name
+	}
+}
+
+// ImageMaxDimension downscales images so that neither side exceeds px pixels.
+func ImageMaxDimension(px int) MediaOption {
+	return func(c *mediaConfig) {
+		c.maxDimension = px
+	}
+}
+
+// ImageFormat sets ImageUrl.Format (e.g. "image/jpeg").
+func ImageFormat(format string) MediaOption {
+	return func(c *mediaConfig) {
+		c.imageFormat = format
+	}
+}
+
+// ImageDetail sets ImageUrl.Detail ("auto", "low" or "high").
+func ImageDetail(detail string) MediaOption {
+	return func(c *mediaConfig) {
+		c.imageDetail = detail
+	}
+}
+
+func newMediaConfig(options []MediaOption) mediaConfig {
+	c := mediaConfig{maxBytes: DefaultMaxMediaBytes}
+	for _, option := range options {
+		option(&c)
+	}
+	return c
+}
+
+// ImageFromFile reads a local image and encodes it as a data URL.
+func ImageFromFile(path string, options ...MediaOption) (ImageUrl, error) {
+	data, c, err := readMediaFile(path, options)
+	if err != nil {
+		return ImageUrl{}, err
+	}
+	return imageFromBytes(data, c)
+}
+
+// ImageFromReader reads an image from r and encodes it as a data URL.
+func ImageFromReader(r io.Reader, options ...MediaOption) (ImageUrl, error) {
+	c := newMediaConfig(options)
+	data, err := readMedia(r, c.maxBytes)
+	if err != nil {
+		return ImageUrl{}, err
+	}
+	return imageFromBytes(data, c)
+}
+
+// ImageFromBytes encodes image bytes as a data URL.
+func ImageFromBytes(data []byte, options ...MediaOption) (ImageUrl, error) {
+	c := newMediaConfig(options)
+	if err := checkMediaSize(int64(len(data)), c.maxBytes); err != nil {
+		return ImageUrl{}, err
+	}
+	return imageFromBytes(data, c)
+}
+
+// FileFromPath reads a local document (e.g. PDF) into a LiteLLM "file" part.
+func FileFromPath(path string, options ...MediaOption) (File, error) {
+	data, c, err := readMediaFile(path, options)
+	if err != nil {
+		return File{}, err
+	}
+	return fileFromBytes(data, c), nil
+}
+
+// FileFromReader reads a document from r into a LiteLLM "file" part.
+func FileFromReader(r io.Reader, options ...MediaOption) (File, error) {
+	c := newMediaConfig(options)
+	data, err := readMedia(r, c.maxBytes)
+	if err != nil {
+		return File{}, err
+	}
+	return fileFromBytes(data, c), nil
+}
+
+// FileFromBytes wraps document bytes into a LiteLLM "file" part.
+func FileFromBytes(data []byte, options ...MediaOption) (File, error) {
+	c := newMediaConfig(options)
+	if err := checkMediaSize(int64(len(data)), c.maxBytes); err != nil {
+		return File{}, err
+	}
+	return fileFromBytes(data, c), nil
+}
+
+// AudioFromFile reads a local audio file into an "input_audio" part.
+func AudioFromFile(path string, options ...MediaOption) (InputAudio, error) {
+	data, c, err := readMediaFile(path, options)
+	if err != nil {
+		return InputAudio{}, err
+	}
+	return audioFromBytes(data, c)
+}
+
+// AudioFromReader reads audio from r into an "input_audio" part.
+func AudioFromReader(r io.Reader, options ...MediaOption) (InputAudio, error) {
+	c := newMediaConfig(options)
+	data, err := readMedia(r, c.maxBytes)
+	if err != nil {
+		return InputAudio{}, err
+	}
+	return audioFromBytes(data, c)
+}
+
+// AudioFromBytes wraps audio bytes into an "input_audio" part.
+func AudioFromBytes(data []byte, options ...MediaOption) (InputAudio, error) {
+	c := newMediaConfig(options)
+	if err := checkMediaSize(int64(len(data)), c.maxBytes); err != nil {
+		return InputAudio{}, err
+	}
+	return audioFromBytes(data, c)
+}
+
+func readMediaFile(path string, options []MediaOption) ([]byte, mediaConfig, error) {
+	c := newMediaConfig(append([]MediaOption{MediaFilename(filepath.Base(path))}, options...))
+	if c.mimeType == "" {
+		c.mimeType = mime.TypeByExtension(strings.ToLower(filepath.Ext(path)))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, c, fmt.Errorf("failed to open media file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	data, err := readMedia(f, c.maxBytes)
+	if err != nil {
+		return nil, c, fmt.Errorf("failed to read media file %q: %w", path, err)
+	}
+	return data, c, nil
+}
+
+func readMedia(r io.Reader, limit int64) ([]byte, error) {
+	if r == nil {
+		return nil, fmt.Errorf("media reader cannot be nil")
+	}
+	if limit > 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read media: %w", err)
+	}
+	if err := checkMediaSize(int64(len(data)), limit); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func checkMediaSize(size, limit int64) error {
+	if size == 0 {
+		return fmt.Errorf("media is empty")
+	}
+	if limit > 0 && size > limit {
+		return fmt.Errorf("%w: more than %d bytes", ErrMediaTooLarge, limit)
+	}
+	return nil
+}
+
+func detectMIMEType(data []byte, c mediaConfig) string {
+	if c.mimeType != "" {
+		mimeType, _, _ := strings.Cut(c.mimeType, ";")
+		return mimeType
+	}
+	mimeType, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	return mimeType
+}
+
+// DataURL encodes data as a base64 data URL with the given mime type.
+func DataURL(mimeType string, data []byte) string {
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data))
+}
+
+func imageFromBytes(data []byte, c mediaConfig) (ImageUrl, error) {
+	mimeType := detectMIMEType(data, c)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return ImageUrl{}, fmt.Errorf("media is not an image: %s", mimeType)
+	}
+
+	if c.maxDimension > 0 {
+		resized, resizedType, err := downscaleImage(data, c.maxDimension)
+		if err != nil {
+			return ImageUrl{}, err
+		}
+		if resized != nil {
+			data, mimeType = resized, resizedType
+		}
+	}
+
+	return ImageUrl{
+		URL:    DataURL(mimeType, data),
+		Format: c.imageFormat,
+		Detail: c.imageDetail,
+	}, nil
+}
+
+func fileFromBytes(data []byte, c mediaConfig) File {
+	mimeType := detectMIMEType(data, c)
+	return File{
+		FileData: DataURL(mimeType, data),
+		Filename: c.filename,
+		Format:   mimeType,
+	}
+}
+
+func audioFromBytes(data []byte, c mediaConfig) (InputAudio, error) {
+	format := audioFormat(detectMIMEType(data, c))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(c.filename)), ".")
+	}
+	if format == "" {
+		return InputAudio{}, fmt.Errorf("unable to detect audio format")
+	}
+
+	return InputAudio{
+		Data:   base64.StdEncoding.EncodeToString(data),
+		Format: format,
+	}, nil
+}
+
+func audioFormat(mimeType string) string {
+	switch mimeType {
+	case "audio/wav", "audio/wave", "audio/x-wav", "audio/vnd.wave":
+		return "wav"
+	case "audio/mpeg", "audio/mp3":
+		return "mp3"
+	case "audio/ogg", "application/ogg":
+		return "ogg"
+	case "audio/flac", "audio/x-flac":
+		return "flac"
+	case "audio/aac":
+		return "aac"
+	case "audio/mp4", "audio/x-m4a":
+		return "m4a"
+	case "audio/webm":
+		return "webm"
+	}
+	return ""
+}
+
+// downscaleImage shrinks the image to fit into maxDimension x maxDimension.
+// It returns nil data when the image already fits.
+func downscaleImage(data []byte, maxDimension int) ([]byte, string, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return nil, "", nil
+	}
+
+	newWidth, newHeight := maxDimension, maxDimension
+	if width > height {
+		newHeight = max(1, height*maxDimension/width)
+	} else {
+		newWidth = max(1, width*maxDimension/height)
+	}
+
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, src, bounds.Min, draw.Src)
+	dst := boxResize(rgba, newWidth, newHeight)
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 90})
+	} else {
+		format = "png"
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode resized image: %w", err)
+	}
+
+	return buf.Bytes(), "image/" + format, nil
+}
+
+// boxResize averages every source pixel covered by each destination pixel.
+func boxResize(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcH/height, max((y+1)*srcH/height, y*srcH/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcW/width, max((x+1)*srcW/width, x*srcW/width+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					i := sy*src.Stride + sx*4
+					r += uint64(src.Pix[i])
+					g += uint64(src.Pix[i+1])
+					b += uint64(src.Pix[i+2])
+					a += uint64(src.Pix[i+3])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
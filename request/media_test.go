@@ -0,0 +1,140 @@
+package request_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func decodeDataURL(t *testing.T, url string) (string, []byte) {
+	t.Helper()
+	header, payload, ok := strings.Cut(url, ",")
+	require.True(t, ok)
+	data, err := base64.StdEncoding.DecodeString(payload)
+	require.NoError(t, err)
+	return strings.TrimSuffix(strings.TrimPrefix(header, "data:"), ";base64"), data
+}
+
+func TestImageFromBytes(t *testing.T) {
+	data := testPNG(t, 4, 2)
+
+	img, err := request.ImageFromBytes(data, request.ImageDetail(request.ImageDetailHigh), request.ImageFormat("image/png"))
+	require.NoError(t, err)
+
+	mimeType, decoded := decodeDataURL(t, img.URL)
+	assert.Equal(t, "image/png", mimeType)
+	assert.Equal(t, data, decoded)
+	assert.Equal(t, "high", img.Detail)
+	assert.Equal(t, "image/png", img.Format)
+}
+
+func TestImageFromBytes_Downscale(t *testing.T) {
+	img, err := request.ImageFromBytes(testPNG(t, 40, 20), request.ImageMaxDimension(10))
+	require.NoError(t, err)
+
+	mimeType, decoded := decodeDataURL(t, img.URL)
+	assert.Equal(t, "image/png", mimeType)
+
+	resized, _, err := image.Decode(bytes.NewReader(decoded))
+	require.NoError(t, err)
+	assert.Equal(t, 10, resized.Bounds().Dx())
+	assert.Equal(t, 5, resized.Bounds().Dy())
+
+	r, _, _, _ := resized.At(2, 2).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+}
+
+func TestImageFromBytes_Errors(t *testing.T) {
+	_, err := request.ImageFromBytes([]byte("plain text"))
+	assert.ErrorContains(t, err, "not an image")
+
+	_, err = request.ImageFromBytes(testPNG(t, 4, 4), request.MediaMaxBytes(10))
+	assert.ErrorIs(t, err, request.ErrMediaTooLarge)
+
+	_, err = request.ImageFromReader(bytes.NewReader(testPNG(t, 4, 4)), request.MediaMaxBytes(10))
+	assert.ErrorIs(t, err, request.ErrMediaTooLarge)
+
+	_, err = request.ImageFromBytes(nil)
+	assert.ErrorContains(t, err, "empty")
+}
+
+func TestFileFromPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.pdf")
+	data := []byte("%PDF-1.4\n%test\n")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	file, err := request.FileFromPath(path)
+	require.NoError(t, err)
+	assert.Equal(t, "report.pdf", file.Filename)
+	assert.Equal(t, "application/pdf", file.Format)
+
+	mimeType, decoded := decodeDataURL(t, file.FileData)
+	assert.Equal(t, "application/pdf", mimeType)
+	assert.Equal(t, data, decoded)
+
+	msg := request.UserMessageFile("summarize", file)
+	encoded, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `{"type":"file","file":{"file_data":"data:application/pdf;base64,`)
+	assert.Contains(t, string(encoded), `"filename":"report.pdf"`)
+	assert.Equal(t, "summarize [File: report.pdf]", msg.Contents.String())
+}
+
+func TestFileFromReader_Filename(t *testing.T) {
+	file, err := request.FileFromReader(strings.NewReader("a,b\n1,2\n"), request.MediaFilename("data.csv"), request.MediaMIMEType("text/csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "data.csv", file.Filename)
+	assert.Equal(t, "text/csv", file.Format)
+	assert.True(t, strings.HasPrefix(file.FileData, "data:text/csv;base64,"))
+}
+
+func TestAudioFromBytes(t *testing.T) {
+	wav := append([]byte("RIFF\x24\x00\x00\x00WAVEfmt "), make([]byte, 32)...)
+
+	audio, err := request.AudioFromBytes(wav)
+	require.NoError(t, err)
+	assert.Equal(t, "wav", audio.Format)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(wav), audio.Data)
+
+	msg := request.UserMessageAudio("", audio)
+	encoded, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"role":"user","content":[{"type":"input_audio","input_audio":{"data":"`+audio.Data+`","format":"wav"}}]}`, string(encoded))
+
+	_, err = request.AudioFromBytes([]byte("not audio at all"))
+	assert.ErrorContains(t, err, "audio format")
+
+	audio, err = request.AudioFromBytes([]byte("not audio at all"), request.MediaFilename("clip.mp3"))
+	require.NoError(t, err)
+	assert.Equal(t, "mp3", audio.Format)
+}
+
+func TestMessageImage_Options(t *testing.T) {
+	img := request.MessageImage("https://example.com/a.jpg", request.ImageDetail(request.ImageDetailLow), request.ImageFormat("image/jpeg"))
+	assert.Equal(t, request.ImageUrl{URL: "https://example.com/a.jpg", Format: "image/jpeg", Detail: "low"}, img)
+}
@@ -9,6 +9,8 @@ type MessageContent struct {
 	Type         string        `json:"type"`                    // e.g. "text", "image_url", etc.
 	Text         string        `json:"text,omitempty"`          // Text content
 	ImageUrl     *ImageUrl     `json:"image_url,omitempty"`     // Image content, if applicable
+	File         *File         `json:"file,omitempty"`          // Document content, if applicable
+	InputAudio   *InputAudio   `json:"input_audio,omitempty"`   // Audio content, if applicable
 	CacheControl *CacheControl `json:"cache_control,omitempty"` // Prompt cache marker, if applicable
 }
 
@@ -21,6 +23,10 @@ func (mc MessageContents) String() string {
 			resp = append(resp, content.Text)
 		} else if content.Type == "image_url" && content.ImageUrl != nil {
 			resp = append(resp, fmt.Sprintf("[Image: %s]", content.ImageUrl.URL))
+		} else if content.Type == "file" && content.File != nil {
+			resp = append(resp, fmt.Sprintf("[File: %s]", content.File.Filename))
+		} else if content.Type == "input_audio" && content.InputAudio != nil {
+			resp = append(resp, fmt.Sprintf("[Audio: %s]", content.InputAudio.Format))
 		}
 	}
 	return strings.Join(resp, " ")
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -17,6 +18,10 @@ type Message struct {
 	Name       string           `json:"name,omitempty"`
 	ToolCallID string           `json:"tool_call_id,omitempty"`
 	ToolCalls  common.ToolCalls `json:"tool_calls,omitempty"`
+	// ReasoningContent and ThinkingBlocks replay assistant reasoning back to the model.
+	// Extended-thinking models reject tool-use continuations without the signed blocks.
+	ReasoningContent string                `json:"reasoning_content,omitempty"`
+	ThinkingBlocks   common.ThinkingBlocks `json:"thinking_blocks,omitempty"`
 }
 
 // CachePoint marks the message's last content block with default ephemeral cache control.
@@ -70,7 +75,7 @@ func (m *Messages) RemoveEmpty() {
 			}
 			contents = append(contents, c)
 		}
-		if len(contents) == 0 && len(msg.ToolCalls) == 0 {
+		if len(contents) == 0 && len(msg.ToolCalls) == 0 && len(msg.ThinkingBlocks) == 0 {
 			log.Println("ALERT: Message with no valid contents found, skipping")
 			continue
 		}
@@ -80,6 +85,9 @@ func (m *Messages) RemoveEmpty() {
 			Name:       msg.Name,
 			ToolCallID: msg.ToolCallID,
 			ToolCalls:  msg.ToolCalls,
+
+			ReasoningContent: msg.ReasoningContent,
+			ThinkingBlocks:   msg.ThinkingBlocks,
 		})
 	}
 	*m = filtered
@@ -89,9 +97,12 @@ func (m *Message) String() string {
 	return fmt.Sprintf("%s: %s", m.Role, m.Contents)
 }
 
-func MessageImage(url string) ImageUrl {
+func MessageImage(url string, options ...MediaOption) ImageUrl {
+	c := newMediaConfig(options)
 	return ImageUrl{
-		URL: url,
+		URL:    url,
+		Format: c.imageFormat,
+		Detail: c.imageDetail,
 	}
 }
 
@@ -112,6 +123,40 @@ func UserMessageImage(content string, imageUrl ImageUrl) Message {
 	return UserMessage(msg)
 }
 
+func UserMessageFile(content string, file File) Message {
+	msg := make(MessageContents, 0, 2)
+	if content != "" {
+		msg = append(msg, MessageContent{
+			Type: "text",
+			Text: content,
+		})
+	}
+
+	msg = append(msg, MessageContent{
+		Type: "file",
+		File: &file,
+	})
+
+	return UserMessage(msg)
+}
+
+func UserMessageAudio(content string, audio InputAudio) Message {
+	msg := make(MessageContents, 0, 2)
+	if content != "" {
+		msg = append(msg, MessageContent{
+			Type: "text",
+			Text: content,
+		})
+	}
+
+	msg = append(msg, MessageContent{
+		Type:       "input_audio",
+		InputAudio: &audio,
+	})
+
+	return UserMessage(msg)
+}
+
 func SystemMessageSimple(content string) Message {
 	return SystemMessage(MessageContents{{
 		Type: "text",
@@ -163,7 +208,9 @@ func ToolCallMessage(toolCall common.ToolCall, toolResponse response.ToolRespons
 
 func AIMessage(msg response.ResponseMessage) Message {
 	resp := Message{
-		Role: MessageRole(msg.Role),
+		Role:             MessageRole(msg.Role),
+		ReasoningContent: msg.ReasoningContent,
+		ThinkingBlocks:   msg.ThinkingBlocks,
 	}
 
 	if len(msg.ToolCalls) > 0 {
@@ -184,6 +231,31 @@ func AIMessage(msg response.ResponseMessage) Message {
 	return resp
 }
 
+// assistantTexts returns the non-empty text parts of an assistant message, without the "-"
+// AIMessage stores as the text of tool call only messages.
+func assistantTexts(message Message) MessageContents {
+	texts := make(MessageContents, 0, len(message.Contents))
+	for _, part := range message.Contents {
+		if part.Type == "text" && part.Text != "" && (part.Text != "-" || len(message.ToolCalls) == 0) {
+			texts = append(texts, part)
+		}
+	}
+	return texts
+}
+
+// toolCallArguments encodes the arguments of a tool call, missing arguments become {}.
+func toolCallArguments(toolCall common.ToolCall) ([]byte, error) {
+	args := toolCall.Function.Arguments
+	if args == nil {
+		args = common.Arguments{}
+	}
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tool call arguments: %w", err)
+	}
+	return arguments, nil
+}
+
 func AssistantMessageSimple(contents string) Message {
 	if contents == "" {
 		contents = "-"
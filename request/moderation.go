@@ -0,0 +1,111 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+)
+
+// Moderation is the /moderations request body. Input is sent as plain strings,
+// Contents as typed text and image items for multimodal models such as omni-moderation.
+type Moderation struct {
+	Model    models.ModelID    `json:"model"`
+	Input    []string          `json:"-"`
+	Contents []ModerationInput `json:"-"`
+}
+
+type ModerationInput struct {
+	Type     string              `json:"type"`
+	Text     string              `json:"text,omitempty"`
+	ImageURL *ModerationImageURL `json:"image_url,omitempty"`
+}
+
+type ModerationImageURL struct {
+	URL string `json:"url"`
+}
+
+func (m Moderation) MarshalJSON() ([]byte, error) {
+	body := struct {
+		Model models.ModelID `json:"model"`
+		Input any            `json:"input"`
+	}{Model: m.Model, Input: m.Input}
+	if len(m.Contents) > 0 {
+		body.Input = m.Contents
+	}
+	return json.Marshal(body)
+}
+
+// ModerationInputs converts the text and image parts of contents, other parts are skipped.
+func ModerationInputs(contents MessageContents) []ModerationInput {
+	inputs := make([]ModerationInput, 0, len(contents))
+	for _, content := range contents {
+		switch {
+		case content.Type == "text" && content.Text != "":
+			inputs = append(inputs, ModerationInput{Type: "text", Text: content.Text})
+		case content.Type == "image_url" && content.ImageUrl != nil:
+			inputs = append(inputs, ModerationInput{Type: "image_url", ImageURL: &ModerationImageURL{URL: content.ImageUrl.URL}})
+		}
+	}
+	return inputs
+}
+
+// ModerationInputs returns the text and images of the last user message.
+func (r *Request) ModerationInputs() []ModerationInput {
+	for i := len(r.Messages) - 1; i >= 0; i-- {
+		if r.Messages[i].Role == ROLE_USER {
+			return ModerationInputs(r.Messages[i].Contents)
+		}
+	}
+	return nil
+}
+
+// ModerationInputs returns the text and images of the last user message item.
+func (r *Responses) ModerationInputs() []ModerationInput {
+	for i := len(r.Input) - 1; i >= 0; i-- {
+		item := r.Input[i]
+		if item.Role != ROLE_USER {
+			continue
+		}
+		inputs := make([]ModerationInput, 0, len(item.Content))
+		for _, content := range item.Content {
+			switch {
+			case content.Type == RESPONSES_CONTENT_INPUT_TEXT && content.Text != "":
+				inputs = append(inputs, ModerationInput{Type: "text", Text: content.Text})
+			case content.Type == RESPONSES_CONTENT_INPUT_IMAGE && content.ImageURL != "":
+				inputs = append(inputs, ModerationInput{Type: "image_url", ImageURL: &ModerationImageURL{URL: content.ImageURL}})
+			}
+		}
+		return inputs
+	}
+	return nil
+}
+
+// ModerationInputs returns the text and images of the last user message, messages with
+// only tool results are skipped.
+func (r AnthropicMessages) ModerationInputs() []ModerationInput {
+	for i := len(r.Messages) - 1; i >= 0; i-- {
+		message := r.Messages[i]
+		if message.Role != ROLE_USER {
+			continue
+		}
+		inputs := make([]ModerationInput, 0, len(message.Content))
+		toolResults := true
+		for _, block := range message.Content {
+			switch {
+			case block.Type == ANTHROPIC_BLOCK_TEXT && block.Text != "":
+				inputs = append(inputs, ModerationInput{Type: "text", Text: block.Text})
+			case block.Type == ANTHROPIC_BLOCK_IMAGE && block.Source != nil && block.Source.Type == ANTHROPIC_SOURCE_URL:
+				inputs = append(inputs, ModerationInput{Type: "image_url", ImageURL: &ModerationImageURL{URL: block.Source.URL}})
+			case block.Type == ANTHROPIC_BLOCK_IMAGE && block.Source != nil && block.Source.Type == ANTHROPIC_SOURCE_BASE64:
+				url := fmt.Sprintf("data:%s;base64,%s", block.Source.MediaType, block.Source.Data)
+				inputs = append(inputs, ModerationInput{Type: "image_url", ImageURL: &ModerationImageURL{URL: url}})
+			}
+			toolResults = toolResults && block.Type == ANTHROPIC_BLOCK_TOOL_RESULT
+		}
+		if !toolResults {
+			return inputs
+		}
+	}
+	return nil
+}
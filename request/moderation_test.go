@@ -0,0 +1,61 @@
+package request_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func TestModeration_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(request.Moderation{Model: "text-moderation-latest", Input: []string{"hello"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"text-moderation-latest","input":["hello"]}`, string(data))
+
+	message := request.UserMessageImage("look", request.MessageImage("https://example.com/a.png"))
+	contents := request.ModerationInputs(message.Contents)
+	data, err = json.Marshal(request.Moderation{Model: "omni-moderation-latest", Contents: contents})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"omni-moderation-latest","input":[
+		{"type":"text","text":"look"},
+		{"type":"image_url","image_url":{"url":"https://example.com/a.png"}}
+	]}`, string(data))
+}
+
+func TestModerationInputs_LastUserMessage(t *testing.T) {
+	req := request.NewRequest(models.ModelMeta{ModelId: "gpt-4o"})
+	req.SetMessages(request.Messages{request.UserMessageSimple("first"), request.AssistantMessageSimple("ok"), request.UserMessageSimple("second")})
+	assert.Equal(t, []request.ModerationInput{{Type: "text", Text: "second"}}, req.ModerationInputs())
+
+	responses := &request.Responses{Input: []request.ResponsesInputItem{
+		{Type: request.RESPONSES_ITEM_MESSAGE, Role: request.ROLE_USER, Content: []request.ResponsesContent{
+			{Type: request.RESPONSES_CONTENT_INPUT_TEXT, Text: "look"},
+			{Type: request.RESPONSES_CONTENT_INPUT_IMAGE, ImageURL: "https://example.com/a.png"},
+			{Type: request.RESPONSES_CONTENT_INPUT_FILE, FileID: "file-1"},
+		}},
+		{Type: request.RESPONSES_ITEM_FUNCTION_CALL_OUTPUT, CallID: "call-1", Output: "sunny"},
+	}}
+	assert.Equal(t, []request.ModerationInput{
+		{Type: "text", Text: "look"},
+		{Type: "image_url", ImageURL: &request.ModerationImageURL{URL: "https://example.com/a.png"}},
+	}, responses.ModerationInputs())
+}
+
+func TestAnthropicMessages_ModerationInputs(t *testing.T) {
+	req := request.AnthropicMessages{Messages: []request.AnthropicMessage{
+		{Role: request.ROLE_USER, Content: []request.AnthropicContent{
+			request.AnthropicText("what is this?"),
+			{Type: request.ANTHROPIC_BLOCK_IMAGE, Source: &request.AnthropicSource{Type: request.ANTHROPIC_SOURCE_BASE64, MediaType: "image/png", Data: "iVBOR"}},
+		}},
+		{Role: request.ROLE_ASSISTANT, Content: []request.AnthropicContent{{Type: request.ANTHROPIC_BLOCK_TOOL_USE, ID: "toolu-1", Name: "lookup"}}},
+		{Role: request.ROLE_USER, Content: []request.AnthropicContent{{Type: request.ANTHROPIC_BLOCK_TOOL_RESULT, ToolUseID: "toolu-1"}}},
+	}}
+	assert.Equal(t, []request.ModerationInput{
+		{Type: "text", Text: "what is this?"},
+		{Type: "image_url", ImageURL: &request.ModerationImageURL{URL: "data:image/png;base64,iVBOR"}},
+	}, req.ModerationInputs())
+}
@@ -0,0 +1,172 @@
+package request
+
+import "slices"
+
+// DroppedParams returns params that were set on the request but skipped
+// because the model does not list them in SupportedOpenAIParams.
+func (r *Request) DroppedParams() []string {
+	return slices.Clone(r.dropped)
+}
+
+// supportsParam reports whether param is supported and keeps DroppedParams up to date.
+func (r *Request) supportsParam(param string, supportedParams []string) bool {
+	if slices.Contains(supportedParams, param) {
+		r.dropped = slices.DeleteFunc(r.dropped, func(p string) bool { return p == param })
+		return true
+	}
+	r.drop(param)
+	return false
+}
+
+func (r *Request) drop(param string) {
+	if !slices.Contains(r.dropped, param) {
+		r.dropped = append(r.dropped, param)
+	}
+}
+
+// SetMaxTokens sets max_tokens. Use value <= 0 to unset.
+func (r *Request) SetMaxTokens(maxTokens int, supportedParams []string) *Request {
+	if maxTokens <= 0 {
+		return r
+	}
+	if r.supportsParam("max_tokens", supportedParams) {
+		r.MaxTokens = maxTokens
+	}
+	return r
+}
+
+// SetMaxCompletionTokens sets max_completion_tokens. Use value <= 0 to unset.
+func (r *Request) SetMaxCompletionTokens(maxTokens int, supportedParams []string) *Request {
+	if maxTokens <= 0 {
+		return r
+	}
+	if r.supportsParam("max_completion_tokens", supportedParams) {
+		r.MaxCompletionTokens = maxTokens
+	}
+	return r
+}
+
+// SetTopP sets nucleus sampling top_p. Use value -1 to unset.
+func (r *Request) SetTopP(topP float32, supportedParams []string) *Request {
+	if topP < 0 {
+		return r
+	}
+	if r.supportsParam("top_p", supportedParams) {
+		r.TopP = &topP
+	}
+	return r
+}
+
+// SetStop sets stop sequences.
+func (r *Request) SetStop(stop []string, supportedParams []string) *Request {
+	if len(stop) == 0 {
+		return r
+	}
+	if r.supportsParam("stop", supportedParams) {
+		r.Stop = stop
+	}
+	return r
+}
+
+func (r *Request) SetSeed(seed int, supportedParams []string) *Request {
+	if r.supportsParam("seed", supportedParams) {
+		r.Seed = &seed
+	}
+	return r
+}
+
+// SetN sets how many choices to generate. Use value <= 0 to unset.
+func (r *Request) SetN(n int, supportedParams []string) *Request {
+	if n <= 0 {
+		return r
+	}
+	if r.supportsParam("n", supportedParams) {
+		r.N = n
+	}
+	return r
+}
+
+func (r *Request) SetPresencePenalty(penalty float32, supportedParams []string) *Request {
+	if r.supportsParam("presence_penalty", supportedParams) {
+		r.PresencePenalty = &penalty
+	}
+	return r
+}
+
+func (r *Request) SetFrequencyPenalty(penalty float32, supportedParams []string) *Request {
+	if r.supportsParam("frequency_penalty", supportedParams) {
+		r.FrequencyPenalty = &penalty
+	}
+	return r
+}
+
+// SetLogitBias sets token id => bias (-100 to 100).
+func (r *Request) SetLogitBias(bias map[string]int, supportedParams []string) *Request {
+	if len(bias) == 0 {
+		return r
+	}
+	if r.supportsParam("logit_bias", supportedParams) {
+		r.LogitBias = bias
+	}
+	return r
+}
+
+// SetLogprobs enables token log probabilities, disabling them also clears top_logprobs.
+func (r *Request) SetLogprobs(enabled bool, supportedParams []string) *Request {
+	if !enabled {
+		r.Logprobs = false
+		r.TopLogprobs = nil
+		return r
+	}
+	if r.supportsParam("logprobs", supportedParams) {
+		r.Logprobs = true
+	}
+	return r
+}
+
+// SetTopLogprobs requests the n most likely tokens per position. It also enables logprobs,
+// top_logprobs is dropped together with logprobs when the model does not support it.
+func (r *Request) SetTopLogprobs(n int, supportedParams []string) *Request {
+	if n <= 0 {
+		return r
+	}
+	if !r.supportsParam("logprobs", supportedParams) {
+		r.Logprobs = false
+		r.TopLogprobs = nil
+		r.drop("top_logprobs")
+		return r
+	}
+	r.Logprobs = true
+	if r.supportsParam("top_logprobs", supportedParams) {
+		r.TopLogprobs = &n
+	}
+	return r
+}
+
+func (r *Request) SetParallelToolCalls(enabled bool, supportedParams []string) *Request {
+	if r.supportsParam("parallel_tool_calls", supportedParams) {
+		r.ParallelToolCalls = &enabled
+	}
+	return r
+}
+
+// SetUser sets the end-user identifier used for abuse monitoring and spend tracking.
+func (r *Request) SetUser(user string, supportedParams []string) *Request {
+	if user == "" {
+		return r
+	}
+	if r.supportsParam("user", supportedParams) {
+		r.User = user
+	}
+	return r
+}
+
+func (r *Request) SetMetadata(metadata map[string]any, supportedParams []string) *Request {
+	if len(metadata) == 0 {
+		return r
+	}
+	if r.supportsParam("metadata", supportedParams) {
+		r.Metadata = metadata
+	}
+	return r
+}
@@ -0,0 +1,98 @@
+package request_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func TestRequest_SamplingParams(t *testing.T) {
+	supported := []string{
+		"temperature", "max_tokens", "max_completion_tokens", "top_p", "stop", "seed", "n",
+		"presence_penalty", "frequency_penalty", "logit_bias", "logprobs", "top_logprobs",
+		"parallel_tool_calls", "user", "metadata",
+	}
+
+	r := request.NewRequest(models.ModelMeta{ModelId: "test-model"}).
+		SetMaxTokens(100, supported).
+		SetMaxCompletionTokens(200, supported).
+		SetTopP(0, supported).
+		SetStop([]string{"\n\n"}, supported).
+		SetSeed(0, supported).
+		SetN(3, supported).
+		SetPresencePenalty(0.5, supported).
+		SetFrequencyPenalty(-0.5, supported).
+		SetLogitBias(map[string]int{"50256": -100}, supported).
+		SetTopLogprobs(2, supported).
+		SetParallelToolCalls(false, supported).
+		SetUser("user-1", supported).
+		SetMetadata(map[string]any{"job": "nightly"}, supported)
+
+	assert.Empty(t, r.DroppedParams())
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"model":"test-model","messages":null,"stream":false,
+		"max_tokens":100,"max_completion_tokens":200,"top_p":0,"stop":["\n\n"],"seed":0,"n":3,
+		"presence_penalty":0.5,"frequency_penalty":-0.5,"logit_bias":{"50256":-100},
+		"logprobs":true,"top_logprobs":2,"parallel_tool_calls":false,"user":"user-1",
+		"metadata":{"job":"nightly"}
+	}`, string(data))
+}
+
+func TestRequest_DroppedParams(t *testing.T) {
+	supported := []string{"temperature", "max_tokens"}
+
+	r := request.NewRequest(models.ModelMeta{ModelId: "test-model"}).
+		SetTemperature(0.2, supported).
+		SetMaxTokens(10, supported).
+		SetTopP(0.9, supported).
+		SetSeed(42, supported).
+		SetSeed(43, supported).
+		SetTopLogprobs(3, supported).
+		SetParallelToolCalls(true, nil)
+
+	assert.Equal(t, []string{"top_p", "seed", "logprobs", "top_logprobs", "parallel_tool_calls"}, r.DroppedParams())
+	assert.Nil(t, r.TopP)
+	assert.Nil(t, r.Seed)
+	assert.False(t, r.Logprobs)
+	assert.Nil(t, r.TopLogprobs)
+	assert.Nil(t, r.ParallelToolCalls)
+	assert.Equal(t, 10, r.MaxTokens)
+
+	r.SetSeed(42, []string{"seed"})
+	assert.Equal(t, []string{"top_p", "logprobs", "top_logprobs", "parallel_tool_calls"}, r.DroppedParams())
+	assert.Equal(t, 42, *r.Seed)
+
+	r.SetTopLogprobs(3, []string{"logprobs", "top_logprobs"})
+	assert.Equal(t, []string{"top_p", "parallel_tool_calls"}, r.DroppedParams())
+	assert.Equal(t, 3, *r.TopLogprobs)
+
+	r.SetLogprobs(false, nil)
+	assert.False(t, r.Logprobs)
+	assert.Nil(t, r.TopLogprobs)
+}
+
+func TestRequest_SamplingParams_Unset(t *testing.T) {
+	supported := []string{"max_tokens", "top_p", "stop", "n", "user"}
+
+	r := request.NewRequest(models.ModelMeta{ModelId: "test-model"}).
+		SetTemperature(-1, nil).
+		SetMaxTokens(0, supported).
+		SetTopP(-1, supported).
+		SetStop(nil, supported).
+		SetN(0, supported).
+		SetUser("", supported)
+
+	assert.Empty(t, r.DroppedParams())
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"model":"test-model","messages":null,"stream":false}`, string(data))
+}
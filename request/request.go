@@ -11,7 +11,7 @@ type Request struct {
 	Stream         bool            `json:"stream"`
 	Temperature    float32         `json:"temperature,omitempty"`
 	Tools          *LLMCallTools   `json:"tools,omitempty"`
-	ToolChoice     string          `json:"tool_choice,omitempty"`
+	ToolChoice     *ToolChoice     `json:"tool_choice,omitempty"`
 	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 	Functions      string          `json:"functions,omitempty"`
 	// ReasoningEffort enables model "thinking"/reasoning (e.g. "low",
@@ -24,6 +24,26 @@ type Request struct {
 	// CacheControlInjectionPoints configures LiteLLM proxy to automatically insert
 	// ephemeral markers at specific points (e.g. "system", "user").
 	CacheControlInjectionPoints any `json:"cache_control_injection_points,omitempty"`
+	// Cache holds the LiteLLM response cache controls, see SetCache.
+	Cache *LiteLLMCache `json:"cache,omitempty"`
+
+	MaxTokens           int            `json:"max_tokens,omitempty"`
+	MaxCompletionTokens int            `json:"max_completion_tokens,omitempty"`
+	TopP                *float32       `json:"top_p,omitempty"`
+	Stop                []string       `json:"stop,omitempty"`
+	Seed                *int           `json:"seed,omitempty"`
+	N                   int            `json:"n,omitempty"`
+	PresencePenalty     *float32       `json:"presence_penalty,omitempty"`
+	FrequencyPenalty    *float32       `json:"frequency_penalty,omitempty"`
+	LogitBias           map[string]int `json:"logit_bias,omitempty"`
+	Logprobs            bool           `json:"logprobs,omitempty"`
+	TopLogprobs         *int           `json:"top_logprobs,omitempty"`
+	ParallelToolCalls   *bool          `json:"parallel_tool_calls,omitempty"`
+	User                string         `json:"user,omitempty"`
+	Metadata            map[string]any `json:"metadata,omitempty"`
+
+	// dropped holds params that were requested but are not supported by the model.
+	dropped []string
 }
 
 // TokenCounterRequest represents the request body for the LiteLLM /utils/token_counter endpoint.
@@ -53,11 +73,8 @@ func (r *Request) SetTemperature(temp float32, supportedParams []string) *Reques
 	if temp < 0 {
 		return r
 	}
-	for _, param := range supportedParams {
-		if param == "temperature" {
-			r.Temperature = temp
-			break
-		}
+	if r.supportsParam("temperature", supportedParams) {
+		r.Temperature = temp
 	}
 	return r
 }
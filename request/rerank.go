@@ -0,0 +1,16 @@
+package request
+
+import "github.com/andrejsstepanovs/go-litellm/models"
+
+// Rerank is the /rerank request body.
+type Rerank struct {
+	Model     models.ModelID `json:"model"`
+	Query     string         `json:"query"`
+	Documents []string       `json:"documents"`
+	// TopN limits the results, 0 returns all documents.
+	TopN int `json:"top_n,omitempty"`
+	// ReturnDocuments echoes the document text in every result.
+	ReturnDocuments bool `json:"return_documents,omitempty"`
+	// MaxChunksPerDoc is passed to providers that split long documents, e.g. Cohere.
+	MaxChunksPerDoc int `json:"max_chunks_per_doc,omitempty"`
+}
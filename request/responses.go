@@ -0,0 +1,260 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+)
+
+const (
+	RESPONSES_ITEM_MESSAGE              = "message"
+	RESPONSES_ITEM_FUNCTION_CALL        = "function_call"
+	RESPONSES_ITEM_FUNCTION_CALL_OUTPUT = "function_call_output"
+
+	RESPONSES_CONTENT_INPUT_TEXT  = "input_text"
+	RESPONSES_CONTENT_INPUT_IMAGE = "input_image"
+	RESPONSES_CONTENT_INPUT_FILE  = "input_file"
+	RESPONSES_CONTENT_OUTPUT_TEXT = "output_text"
+
+	RESPONSES_TOOL_WEB_SEARCH       = "web_search_preview"
+	RESPONSES_TOOL_FILE_SEARCH      = "file_search"
+	RESPONSES_TOOL_CODE_INTERPRETER = "code_interpreter"
+)
+
+// Responses is the /v1/responses request body.
+// https://platform.openai.com/docs/api-reference/responses/create
+type Responses struct {
+	Model models.ModelID       `json:"model"`
+	Input []ResponsesInputItem `json:"input"`
+	// Instructions replace the system prompt, they are not carried over by PreviousResponseID.
+	Instructions string `json:"instructions,omitempty"`
+	// PreviousResponseID continues a conversation stored on the server, Input then holds only the new items.
+	PreviousResponseID string               `json:"previous_response_id,omitempty"`
+	Tools              []ResponsesTool      `json:"tools,omitempty"`
+	ToolChoice         *ResponsesToolChoice `json:"tool_choice,omitempty"`
+	ParallelToolCalls  *bool                `json:"parallel_tool_calls,omitempty"`
+	Temperature        *float32             `json:"temperature,omitempty"`
+	TopP               *float32             `json:"top_p,omitempty"`
+	MaxOutputTokens    int                  `json:"max_output_tokens,omitempty"`
+	Reasoning          *ResponsesReason     `json:"reasoning,omitempty"`
+	Text               *ResponsesText       `json:"text,omitempty"`
+	// Store keeps the response for PreviousResponseID, the API default is true.
+	Store    *bool             `json:"store,omitempty"`
+	Stream   bool              `json:"stream,omitempty"`
+	Include  []string          `json:"include,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	User     string            `json:"user,omitempty"`
+}
+
+// ResponsesInputItem is a message, a function call of the model or the output of a function call.
+type ResponsesInputItem struct {
+	Type    string             `json:"type"`
+	Role    MessageRole        `json:"role,omitempty"`
+	Content []ResponsesContent `json:"content,omitempty"`
+	// CallID, Name and Arguments are set for function calls, CallID and Output for their outputs.
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+	Output    string `json:"output,omitempty"`
+}
+
+type ResponsesContent struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	FileID   string `json:"file_id,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// ResponsesToolChoice is ToolChoice in the responses api shape, named functions are not nested.
+type ResponsesToolChoice ToolChoice
+
+func (tc ResponsesToolChoice) MarshalJSON() ([]byte, error) {
+	if tc.Function != nil {
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+		}{
+			Type: FunctionToolType,
+			Name: tc.Function.Name,
+		})
+	}
+	return json.Marshal(string(tc.Mode))
+}
+
+// ResponsesTool is a function or a built-in tool such as RESPONSES_TOOL_WEB_SEARCH.
+type ResponsesTool struct {
+	Type        string                         `json:"type"`
+	Name        string                         `json:"name,omitempty"`
+	Description string                         `json:"description,omitempty"`
+	Parameters  *LLMCallToolFunctionParameters `json:"parameters,omitempty"`
+	Strict      *bool                          `json:"strict,omitempty"`
+	// VectorStoreIDs are searched by RESPONSES_TOOL_FILE_SEARCH.
+	VectorStoreIDs    []string `json:"vector_store_ids,omitempty"`
+	SearchContextSize string   `json:"search_context_size,omitempty"`
+	// Container is required by RESPONSES_TOOL_CODE_INTERPRETER, e.g. map[string]string{"type": "auto"}.
+	Container any `json:"container,omitempty"`
+}
+
+type ResponsesReason struct {
+	Effort string `json:"effort,omitempty"`
+	// Summary is "auto", "concise" or "detailed".
+	Summary string `json:"summary,omitempty"`
+}
+
+type ResponsesText struct {
+	Format ResponsesTextFormat `json:"format"`
+}
+
+// ResponsesTextFormat is "text", "json_object" or "json_schema" with Name, Schema and Strict.
+type ResponsesTextFormat struct {
+	Type   string         `json:"type"`
+	Name   string         `json:"name,omitempty"`
+	Schema map[string]any `json:"schema,omitempty"`
+	Strict bool           `json:"strict,omitempty"`
+}
+
+// ValidateToolChoice checks ToolChoice against Tools, built-in tools count for "required".
+func (r *Responses) ValidateToolChoice() error {
+	if r.ToolChoice == nil {
+		return nil
+	}
+	choice := ToolChoice(*r.ToolChoice)
+	if choice.Function != nil {
+		functions := make(LLMCallTools, 0, len(r.Tools))
+		for _, tool := range r.Tools {
+			if tool.Type == FunctionToolType {
+				functions = append(functions, LLMCallTool{Type: FunctionToolType, Function: &LLMCallToolFunction{Name: tool.Name}})
+			}
+		}
+		return choice.Validate(&functions)
+	}
+	if choice.Mode == TOOL_CHOICE_REQUIRED && len(r.Tools) > 0 {
+		return nil
+	}
+	return choice.Validate(nil)
+}
+
+// ToResponses converts a chat completion request, so existing code can switch endpoints.
+// Messages, tools, tool choice, sampling params, max tokens, response format,
+// reasoning effort and user are carried over.
+func (r *Request) ToResponses() (*Responses, error) {
+	input, err := ResponsesInput(r.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Responses{
+		Model:             r.Model,
+		Input:             input,
+		ParallelToolCalls: r.ParallelToolCalls,
+		TopP:              r.TopP,
+		MaxOutputTokens:   max(r.MaxCompletionTokens, r.MaxTokens),
+		Stream:            r.Stream,
+		User:              r.User,
+	}
+	if r.Temperature != 0 {
+		temperature := r.Temperature
+		res.Temperature = &temperature
+	}
+	if r.Tools != nil {
+		res.Tools = ResponsesTools(*r.Tools)
+	}
+	if r.ToolChoice != nil {
+		choice := ResponsesToolChoice(*r.ToolChoice)
+		res.ToolChoice = &choice
+	}
+	if r.ReasoningEffort != "" {
+		res.Reasoning = &ResponsesReason{Effort: r.ReasoningEffort}
+	}
+	if r.ResponseFormat != nil {
+		format := ResponsesTextFormat{Type: r.ResponseFormat.Type}
+		if r.ResponseFormat.Type == "json_schema" {
+			format.Name = r.ResponseFormat.JSONSchema.Name
+			format.Schema = r.ResponseFormat.JSONSchema.Schema
+			format.Strict = r.ResponseFormat.JSONSchema.Strict
+		}
+		res.Text = &ResponsesText{Format: format}
+	}
+	return res, nil
+}
+
+// ResponsesInput converts chat messages to input items. Tool calls of assistant messages become
+// function call items and tool messages their outputs. Audio parts are not supported.
+func ResponsesInput(messages Messages) ([]ResponsesInputItem, error) {
+	items := make([]ResponsesInputItem, 0, len(messages))
+	for i, message := range messages {
+		switch message.Role {
+		case ROLE_TOOL:
+			items = append(items, ResponsesInputItem{
+				Type:   RESPONSES_ITEM_FUNCTION_CALL_OUTPUT,
+				CallID: message.ToolCallID,
+				Output: message.Contents.String(),
+			})
+			continue
+		case ROLE_ASSISTANT:
+			content := make([]ResponsesContent, 0, len(message.Contents))
+			for _, part := range assistantTexts(message) {
+				content = append(content, ResponsesContent{Type: RESPONSES_CONTENT_OUTPUT_TEXT, Text: part.Text})
+			}
+			if len(content) > 0 {
+				items = append(items, ResponsesInputItem{Type: RESPONSES_ITEM_MESSAGE, Role: ROLE_ASSISTANT, Content: content})
+			}
+			for _, toolCall := range message.ToolCalls {
+				arguments, err := toolCallArguments(toolCall)
+				if err != nil {
+					return nil, fmt.Errorf("message %d: %w", i, err)
+				}
+				items = append(items, ResponsesInputItem{
+					Type:      RESPONSES_ITEM_FUNCTION_CALL,
+					CallID:    toolCall.ID,
+					Name:      toolCall.Function.Name,
+					Arguments: string(arguments),
+				})
+			}
+			continue
+		}
+
+		content := make([]ResponsesContent, 0, len(message.Contents))
+		for _, part := range message.Contents {
+			switch {
+			case part.Type == "text":
+				content = append(content, ResponsesContent{Type: RESPONSES_CONTENT_INPUT_TEXT, Text: part.Text})
+			case part.Type == "image_url" && part.ImageUrl != nil:
+				content = append(content, ResponsesContent{Type: RESPONSES_CONTENT_INPUT_IMAGE, ImageURL: part.ImageUrl.URL, Detail: part.ImageUrl.Detail})
+			case part.Type == "file" && part.File != nil:
+				content = append(content, ResponsesContent{
+					Type:     RESPONSES_CONTENT_INPUT_FILE,
+					FileID:   part.File.FileID,
+					FileData: part.File.FileData,
+					Filename: part.File.Filename,
+				})
+			default:
+				return nil, fmt.Errorf("message %d: content type %q is not supported by the responses api", i, part.Type)
+			}
+		}
+		items = append(items, ResponsesInputItem{Type: RESPONSES_ITEM_MESSAGE, Role: message.Role, Content: content})
+	}
+	return items, nil
+}
+
+// ResponsesTools converts chat function tools, the responses api has no nested "function" object.
+func ResponsesTools(tools LLMCallTools) []ResponsesTool {
+	converted := make([]ResponsesTool, 0, len(tools))
+	for _, tool := range tools {
+		if tool.Function == nil {
+			converted = append(converted, ResponsesTool{Type: tool.Type})
+			continue
+		}
+		converted = append(converted, ResponsesTool{
+			Type:        FunctionToolType,
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Parameters:  tool.Function.Parameters,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,101 @@
+package request_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func TestRequest_ToResponses(t *testing.T) {
+	toolCall := common.ToolCall{
+		ID:   "call-1",
+		Type: "function",
+		Function: common.ToolCallFunction{
+			Name:      "get_weather",
+			Arguments: common.Arguments{"city": "Riga"},
+		},
+	}
+	messages := request.Messages{
+		request.SystemMessageSimple("be brief"),
+		request.UserMessageImage("weather here?", request.MessageImage("https://example.com/a.png")),
+		request.AIMessage(response.ResponseMessage{Role: "assistant", ToolCalls: common.ToolCalls{toolCall}}),
+		request.ToolCallMessage(toolCall, response.ToolResponse{Text: "sunny"}),
+		request.AssistantMessageSimple("It is sunny."),
+	}
+	tools := request.LLMCallTools{{
+		Type: request.FunctionToolType,
+		Function: &request.LLMCallToolFunction{
+			Name:        "get_weather",
+			Description: "Current weather",
+			Parameters:  &request.LLMCallToolFunctionParameters{Type: "object", Required: []string{"city"}},
+		},
+	}}
+	req := &request.Request{
+		Model:          "gpt-5-mini",
+		Messages:       messages,
+		Tools:          &tools,
+		Temperature:    0.2,
+		MaxTokens:      100,
+		ResponseFormat: &request.ResponseFormat{Type: "json_schema", JSONSchema: request.JSONSchema{Name: "weather", Strict: true}},
+	}
+	req.SetToolChoice(request.ToolChoiceFunction("get_weather"))
+
+	res, err := req.ToResponses()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"model":"gpt-5-mini",
+		"input":[
+			{"type":"message","role":"system","content":[{"type":"input_text","text":"be brief"}]},
+			{"type":"message","role":"user","content":[
+				{"type":"input_text","text":"weather here?"},
+				{"type":"input_image","image_url":"https://example.com/a.png"}
+			]},
+			{"type":"function_call","call_id":"call-1","name":"get_weather","arguments":"{\"city\":\"Riga\"}"},
+			{"type":"function_call_output","call_id":"call-1","output":"sunny"},
+			{"type":"message","role":"assistant","content":[{"type":"output_text","text":"It is sunny."}]}
+		],
+		"tools":[{"type":"function","name":"get_weather","description":"Current weather","parameters":{"type":"object","required":["city"]}}],
+		"tool_choice":{"type":"function","name":"get_weather"},
+		"temperature":0.2,
+		"max_output_tokens":100,
+		"text":{"format":{"type":"json_schema","name":"weather","strict":true}}
+	}`, string(data))
+}
+
+func TestResponsesInput_UnsupportedContent(t *testing.T) {
+	messages := request.Messages{request.UserMessageAudio("", request.InputAudio{Data: "AAAA", Format: "wav"})}
+	_, err := request.ResponsesInput(messages)
+	assert.EqualError(t, err, `message 0: content type "input_audio" is not supported by the responses api`)
+}
+
+func TestResponses_ValidateToolChoice(t *testing.T) {
+	choice := func(tc request.ToolChoice) *request.ResponsesToolChoice {
+		rc := request.ResponsesToolChoice(tc)
+		return &rc
+	}
+	req := &request.Responses{Tools: []request.ResponsesTool{
+		{Type: request.FunctionToolType, Name: "get_weather"},
+		{Type: request.RESPONSES_TOOL_WEB_SEARCH},
+	}}
+	assert.NoError(t, req.ValidateToolChoice())
+
+	req.ToolChoice = choice(request.ToolChoiceFunction("get_weather"))
+	assert.NoError(t, req.ValidateToolChoice())
+	req.ToolChoice = choice(request.ToolChoiceFunction("get_wether"))
+	assert.EqualError(t, req.ValidateToolChoice(), `tool_choice function "get_wether" is not in request tools`)
+
+	req.ToolChoice = choice(request.ToolChoiceRequired())
+	req.Tools = req.Tools[1:]
+	assert.NoError(t, req.ValidateToolChoice(), "built-in tools can be required")
+	req.Tools = nil
+	assert.EqualError(t, req.ValidateToolChoice(), `tool_choice "required" requires request tools`)
+}
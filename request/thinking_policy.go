@@ -0,0 +1,58 @@
+package request
+
+import (
+	"slices"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+)
+
+// ThinkingPolicy decides what happens to assistant reasoning when history is replayed.
+type ThinkingPolicy string
+
+const (
+	// THINKING_KEEP sends reasoning_content and thinking_blocks back unchanged.
+	THINKING_KEEP ThinkingPolicy = "keep"
+	// THINKING_STRIP removes reasoning from assistant messages.
+	THINKING_STRIP ThinkingPolicy = "strip"
+	// THINKING_REDACT drops readable reasoning and signed thinking blocks but keeps
+	// redacted_thinking data, e.g. for storing conversations. Use THINKING_KEEP to
+	// continue a tool-use turn, it needs the signed blocks.
+	THINKING_REDACT ThinkingPolicy = "redact"
+)
+
+// thinkingProviders validate signed thinking blocks on tool-use continuations.
+var thinkingProviders = []string{"anthropic", "bedrock", "bedrock_converse", "vertex_ai"}
+
+// ThinkingPolicyFor returns THINKING_KEEP for providers that require thinking
+// blocks to be replayed and THINKING_STRIP for everyone else.
+func ThinkingPolicyFor(model models.ModelMeta) ThinkingPolicy {
+	for _, provider := range model.Providers {
+		if slices.Contains(thinkingProviders, provider) {
+			return THINKING_KEEP
+		}
+	}
+	return THINKING_STRIP
+}
+
+// WithThinkingPolicy returns a copy of the messages with the policy applied to assistant messages.
+func (m Messages) WithThinkingPolicy(policy ThinkingPolicy) Messages {
+	if m == nil {
+		return nil
+	}
+
+	result := make(Messages, len(m))
+	for i, msg := range m {
+		if msg.Role == ROLE_ASSISTANT {
+			switch policy {
+			case THINKING_STRIP:
+				msg.ReasoningContent = ""
+				msg.ThinkingBlocks = nil
+			case THINKING_REDACT:
+				msg.ReasoningContent = ""
+				msg.ThinkingBlocks = msg.ThinkingBlocks.Redacted()
+			}
+		}
+		result[i] = msg
+	}
+	return result
+}
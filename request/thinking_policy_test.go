@@ -0,0 +1,72 @@
+package request_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func thinkingResponseMessage() response.ResponseMessage {
+	return response.ResponseMessage{
+		Role:             "assistant",
+		ReasoningContent: "I should check the time.",
+		ThinkingBlocks: common.ThinkingBlocks{
+			{Type: common.THINKING_BLOCK_THINKING, Thinking: "I should check the time.", Signature: "sig-1"},
+			{Type: common.THINKING_BLOCK_REDACTED, Data: "encrypted"},
+		},
+		ToolCalls: common.ToolCalls{{ID: "call-1", Type: "function", Function: common.ToolCallFunction{Name: "current_time"}}},
+	}
+}
+
+func TestAIMessage_PreservesThinkingBlocks(t *testing.T) {
+	msg := request.AIMessage(thinkingResponseMessage())
+
+	assert.Equal(t, "I should check the time.", msg.ReasoningContent)
+	assert.Equal(t, "sig-1", msg.ThinkingBlocks[0].Signature)
+	assert.Equal(t, "encrypted", msg.ThinkingBlocks[1].Data)
+
+	messages := request.Messages{request.UserMessageSimple("time?"), msg}
+	messages.RemoveEmpty()
+	assert.Equal(t, msg.ThinkingBlocks, messages[1].ThinkingBlocks)
+	assert.Equal(t, msg.ReasoningContent, messages[1].ReasoningContent)
+
+	data, err := json.Marshal(messages[1])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"reasoning_content":"I should check the time."`)
+	assert.Contains(t, string(data), `"thinking_blocks":[{"type":"thinking","thinking":"I should check the time.","signature":"sig-1"},{"type":"redacted_thinking","data":"encrypted"}]`)
+}
+
+func TestMessages_WithThinkingPolicy(t *testing.T) {
+	messages := request.Messages{request.UserMessageSimple("time?"), request.AIMessage(thinkingResponseMessage())}
+
+	kept := messages.WithThinkingPolicy(request.THINKING_KEEP)
+	assert.Equal(t, messages, kept)
+
+	stripped := messages.WithThinkingPolicy(request.THINKING_STRIP)
+	assert.Empty(t, stripped[1].ReasoningContent)
+	assert.Nil(t, stripped[1].ThinkingBlocks)
+	assert.Len(t, stripped[1].ToolCalls, 1)
+
+	redacted := messages.WithThinkingPolicy(request.THINKING_REDACT)
+	assert.Empty(t, redacted[1].ReasoningContent)
+	assert.Equal(t, common.ThinkingBlocks{
+		{Type: common.THINKING_BLOCK_REDACTED, Data: "encrypted"},
+	}, redacted[1].ThinkingBlocks)
+
+	// original messages are not modified
+	assert.Equal(t, "I should check the time.", messages[1].ThinkingBlocks[0].Thinking)
+}
+
+func TestThinkingPolicyFor(t *testing.T) {
+	assert.Equal(t, request.THINKING_KEEP, request.ThinkingPolicyFor(models.ModelMeta{Providers: []string{"anthropic"}}))
+	assert.Equal(t, request.THINKING_KEEP, request.ThinkingPolicyFor(models.ModelMeta{Providers: []string{"openai", "bedrock"}}))
+	assert.Equal(t, request.THINKING_STRIP, request.ThinkingPolicyFor(models.ModelMeta{Providers: []string{"openai"}}))
+	assert.Equal(t, request.THINKING_STRIP, request.ThinkingPolicyFor(models.ModelMeta{}))
+}
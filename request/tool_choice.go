@@ -0,0 +1,117 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// "tool_choice": "auto" | "none" | "required"
+// "tool_choice": {"type": "function", "function": {"name": "get_weather"}}
+
+type ToolChoiceMode string
+
+const (
+	TOOL_CHOICE_AUTO     ToolChoiceMode = "auto"
+	TOOL_CHOICE_NONE     ToolChoiceMode = "none"
+	TOOL_CHOICE_REQUIRED ToolChoiceMode = "required"
+)
+
+type ToolChoiceFunctionName struct {
+	Name string `json:"name"`
+}
+
+// ToolChoice is either a mode ("auto", "none", "required") or a named function.
+type ToolChoice struct {
+	Mode     ToolChoiceMode
+	Function *ToolChoiceFunctionName
+}
+
+func ToolChoiceAuto() ToolChoice {
+	return ToolChoice{Mode: TOOL_CHOICE_AUTO}
+}
+
+func ToolChoiceNone() ToolChoice {
+	return ToolChoice{Mode: TOOL_CHOICE_NONE}
+}
+
+func ToolChoiceRequired() ToolChoice {
+	return ToolChoice{Mode: TOOL_CHOICE_REQUIRED}
+}
+
+// ToolChoiceFunction forces the model to call the named function.
+func ToolChoiceFunction(name string) ToolChoice {
+	return ToolChoice{Function: &ToolChoiceFunctionName{Name: name}}
+}
+
+func (tc ToolChoice) MarshalJSON() ([]byte, error) {
+	if tc.Function != nil {
+		return json.Marshal(struct {
+			Type     string                 `json:"type"`
+			Function ToolChoiceFunctionName `json:"function"`
+		}{
+			Type:     FunctionToolType,
+			Function: *tc.Function,
+		})
+	}
+	return json.Marshal(string(tc.Mode))
+}
+
+func (tc *ToolChoice) UnmarshalJSON(data []byte) error {
+	var mode string
+	if err := json.Unmarshal(data, &mode); err == nil {
+		*tc = ToolChoice{Mode: ToolChoiceMode(mode)}
+		return nil
+	}
+
+	var named struct {
+		Type     string                 `json:"type"`
+		Function ToolChoiceFunctionName `json:"function"`
+	}
+	if err := json.Unmarshal(data, &named); err != nil {
+		return fmt.Errorf("error unmarshalling tool_choice: %w", err)
+	}
+	*tc = ToolChoice{Function: &named.Function}
+	return nil
+}
+
+// Validate checks the choice against the tools that are sent with the request.
+func (tc ToolChoice) Validate(tools *LLMCallTools) error {
+	if tc.Function != nil {
+		if tc.Function.Name == "" {
+			return fmt.Errorf("tool_choice function name cannot be empty")
+		}
+		if tools != nil {
+			for _, tool := range *tools {
+				if tool.Function != nil && tool.Function.Name == tc.Function.Name {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("tool_choice function %q is not in request tools", tc.Function.Name)
+	}
+
+	switch tc.Mode {
+	case TOOL_CHOICE_AUTO, TOOL_CHOICE_NONE:
+		return nil
+	case TOOL_CHOICE_REQUIRED:
+		if tools == nil || len(*tools) == 0 {
+			return fmt.Errorf("tool_choice %q requires request tools", tc.Mode)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown tool_choice %q", tc.Mode)
+}
+
+func (r *Request) SetToolChoice(choice ToolChoice) *Request {
+	r.ToolChoice = &choice
+	return r
+}
+
+// ValidateToolChoice checks ToolChoice against Tools, so typos fail before the request is sent.
+func (r *Request) ValidateToolChoice() error {
+	if r.ToolChoice == nil {
+		return nil
+	}
+	return r.ToolChoice.Validate(r.Tools)
+}
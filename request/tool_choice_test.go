@@ -0,0 +1,99 @@
+package request_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func TestToolChoice_Marshal(t *testing.T) {
+	tests := []struct {
+		name         string
+		choice       request.ToolChoice
+		expectedJSON string
+	}{
+		{name: "auto", choice: request.ToolChoiceAuto(), expectedJSON: `"auto"`},
+		{name: "none", choice: request.ToolChoiceNone(), expectedJSON: `"none"`},
+		{name: "required", choice: request.ToolChoiceRequired(), expectedJSON: `"required"`},
+		{
+			name:         "named function",
+			choice:       request.ToolChoiceFunction("get_weather"),
+			expectedJSON: `{"type":"function","function":{"name":"get_weather"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.choice)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedJSON, string(data))
+
+			var decoded request.ToolChoice
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, tt.choice, decoded)
+		})
+	}
+}
+
+func TestToolChoice_Validate(t *testing.T) {
+	tools := &request.LLMCallTools{
+		{Type: "function", Function: &request.LLMCallToolFunction{Name: "get_weather"}},
+	}
+
+	tests := []struct {
+		name        string
+		choice      request.ToolChoice
+		tools       *request.LLMCallTools
+		expectedErr string
+	}{
+		{name: "auto without tools", choice: request.ToolChoiceAuto()},
+		{name: "none without tools", choice: request.ToolChoiceNone()},
+		{name: "required with tools", choice: request.ToolChoiceRequired(), tools: tools},
+		{name: "required without tools", choice: request.ToolChoiceRequired(), expectedErr: `tool_choice "required" requires request tools`},
+		{name: "known function", choice: request.ToolChoiceFunction("get_weather"), tools: tools},
+		{name: "typo in function", choice: request.ToolChoiceFunction("get_wether"), tools: tools, expectedErr: `tool_choice function "get_wether" is not in request tools`},
+		{name: "empty function name", choice: request.ToolChoiceFunction(""), tools: tools, expectedErr: "function name cannot be empty"},
+		{name: "unknown mode", choice: request.ToolChoice{Mode: "always"}, expectedErr: `unknown tool_choice "always"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.choice.Validate(tt.tools)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestRequest_SetToolChoice(t *testing.T) {
+	r := request.NewRequest(models.ModelMeta{ModelId: "test-model"}).
+		SetAvailableTools(request.LLMCallTools{{Type: "function", Function: &request.LLMCallToolFunction{Name: "get_weather"}}}).
+		SetToolChoice(request.ToolChoiceFunction("get_weather"))
+	require.NoError(t, r.ValidateToolChoice())
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"tool_choice":{"type":"function","function":{"name":"get_weather"}}`)
+
+	assert.NoError(t, request.NewRequest(models.ModelMeta{ModelId: "test-model"}).ValidateToolChoice())
+}
+
+func TestRequest_ToolChoiceJSON(t *testing.T) {
+	// requests stored before tool_choice was typed carry a bare mode string
+	var req request.Request
+	require.NoError(t, json.Unmarshal([]byte(`{"model":"gpt-4o","messages":[],"tool_choice":"required"}`), &req))
+	require.NotNil(t, req.ToolChoice)
+	assert.Equal(t, request.ToolChoiceRequired(), *req.ToolChoice)
+
+	data, err := json.Marshal(request.NewRequest(models.ModelMeta{ModelId: "gpt-4o"}).SetToolChoice(request.ToolChoiceAuto()))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"tool_choice":"auto"`)
+}
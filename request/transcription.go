@@ -0,0 +1,83 @@
+package request
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+const (
+	TRANSCRIPTION_FORMAT_JSON         = "json"
+	TRANSCRIPTION_FORMAT_VERBOSE_JSON = "verbose_json"
+	TRANSCRIPTION_FORMAT_SRT          = "srt"
+	TRANSCRIPTION_FORMAT_VTT          = "vtt"
+	TRANSCRIPTION_FORMAT_TEXT         = "text"
+
+	TIMESTAMP_GRANULARITY_WORD    = "word"
+	TIMESTAMP_GRANULARITY_SEGMENT = "segment"
+)
+
+// Transcription holds the optional fields of /audio/transcriptions and /audio/translations.
+type Transcription struct {
+	// Optional ISO-639-1 input language, e.g. "en". Ignored by translations.
+	Language string
+	// Optional text to guide the model's style or continue a previous segment.
+	Prompt string
+	// Optional json (default), verbose_json, srt, vtt or text.
+	ResponseFormat string
+	// Optional "word" and/or "segment". Requires verbose_json.
+	TimestampGranularities []string
+	// Optional sampling temperature between 0 and 1.
+	Temperature *float32
+	// ExtraBody passes provider specific fields (e.g. deepgram "smart_format").
+	ExtraBody map[string]any
+}
+
+func (t Transcription) Validate() error {
+	switch t.ResponseFormat {
+	case "", TRANSCRIPTION_FORMAT_JSON, TRANSCRIPTION_FORMAT_VERBOSE_JSON, TRANSCRIPTION_FORMAT_SRT, TRANSCRIPTION_FORMAT_VTT, TRANSCRIPTION_FORMAT_TEXT:
+	default:
+		return fmt.Errorf("unsupported transcription response_format %q", t.ResponseFormat)
+	}
+
+	for _, granularity := range t.TimestampGranularities {
+		if granularity != TIMESTAMP_GRANULARITY_WORD && granularity != TIMESTAMP_GRANULARITY_SEGMENT {
+			return fmt.Errorf("unsupported timestamp granularity %q", granularity)
+		}
+	}
+	if len(t.TimestampGranularities) > 0 && t.ResponseFormat != TRANSCRIPTION_FORMAT_VERBOSE_JSON {
+		return fmt.Errorf("timestamp granularities require response_format %q", TRANSCRIPTION_FORMAT_VERBOSE_JSON)
+	}
+
+	if t.Temperature != nil && (*t.Temperature < 0 || *t.Temperature > 1) {
+		return fmt.Errorf("transcription temperature must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// Fields returns multipart form fields. Typed fields override ExtraBody.
+func (t Transcription) Fields() map[string]any {
+	fields := make(map[string]any, len(t.ExtraBody)+5)
+	for key, value := range t.ExtraBody {
+		fields[key] = value
+	}
+
+	if t.Language != "" {
+		fields["language"] = t.Language
+	}
+	if t.Prompt != "" {
+		fields["prompt"] = t.Prompt
+	}
+	if t.ResponseFormat != "" {
+		fields["response_format"] = t.ResponseFormat
+	}
+	if len(t.TimestampGranularities) > 0 {
+		fields["timestamp_granularities[]"] = slices.Clone(t.TimestampGranularities)
+	}
+	if t.Temperature != nil {
+		fields["temperature"] = strconv.FormatFloat(float64(*t.Temperature), 'f', -1, 32)
+	}
+
+	return fields
+}
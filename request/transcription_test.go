@@ -0,0 +1,72 @@
+package request_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/andrejsstepanovs/go-litellm/request"
+)
+
+func TestTranscription_Validate(t *testing.T) {
+	temperature := float32(1.5)
+
+	tests := []struct {
+		name        string
+		options     request.Transcription
+		expectedErr string
+	}{
+		{name: "empty", options: request.Transcription{}},
+		{name: "srt", options: request.Transcription{ResponseFormat: request.TRANSCRIPTION_FORMAT_SRT}},
+		{
+			name: "granularities with verbose_json",
+			options: request.Transcription{
+				ResponseFormat:         request.TRANSCRIPTION_FORMAT_VERBOSE_JSON,
+				TimestampGranularities: []string{request.TIMESTAMP_GRANULARITY_WORD},
+			},
+		},
+		{name: "unknown format", options: request.Transcription{ResponseFormat: "xml"}, expectedErr: `unsupported transcription response_format "xml"`},
+		{
+			name:        "granularities without verbose_json",
+			options:     request.Transcription{TimestampGranularities: []string{request.TIMESTAMP_GRANULARITY_WORD}},
+			expectedErr: "require response_format",
+		},
+		{
+			name: "unknown granularity",
+			options: request.Transcription{
+				ResponseFormat:         request.TRANSCRIPTION_FORMAT_VERBOSE_JSON,
+				TimestampGranularities: []string{"char"},
+			},
+			expectedErr: `unsupported timestamp granularity "char"`,
+		},
+		{name: "temperature out of range", options: request.Transcription{Temperature: &temperature}, expectedErr: "between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestTranscription_Fields(t *testing.T) {
+	temperature := float32(0.3)
+	options := request.Transcription{
+		Language:       "en",
+		ResponseFormat: request.TRANSCRIPTION_FORMAT_TEXT,
+		Temperature:    &temperature,
+		ExtraBody:      map[string]any{"smart_format": true, "language": "lv"},
+	}
+
+	assert.Equal(t, map[string]any{
+		"smart_format":    true,
+		"language":        "en",
+		"response_format": "text",
+		"temperature":     "0.3",
+	}, options.Fields())
+}
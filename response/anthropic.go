@@ -0,0 +1,226 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/models"
+)
+
+const (
+	ANTHROPIC_STOP_END_TURN   = "end_turn"
+	ANTHROPIC_STOP_MAX_TOKENS = "max_tokens"
+	ANTHROPIC_STOP_SEQUENCE   = "stop_sequence"
+	ANTHROPIC_STOP_TOOL_USE   = "tool_use"
+	ANTHROPIC_STOP_PAUSE_TURN = "pause_turn"
+	ANTHROPIC_STOP_REFUSAL    = "refusal"
+
+	ANTHROPIC_BLOCK_TEXT     = "text"
+	ANTHROPIC_BLOCK_TOOL_USE = "tool_use"
+
+	ANTHROPIC_EVENT_MESSAGE_STOP = "message_stop"
+	ANTHROPIC_EVENT_ERROR        = "error"
+)
+
+// AnthropicResponse is the /v1/messages result.
+// https://docs.anthropic.com/en/api/messages
+type AnthropicResponse struct {
+	ID           string             `json:"id"`
+	Type         string             `json:"type"`
+	Role         string             `json:"role"`
+	Model        models.ModelID     `json:"model"`
+	Content      []AnthropicContent `json:"content"`
+	StopReason   string             `json:"stop_reason"`
+	StopSequence string             `json:"stop_sequence,omitempty"`
+	Usage        AnthropicUsage     `json:"usage"`
+}
+
+// AnthropicContent is a text, tool_use, thinking or redacted_thinking block.
+// Server tool blocks (e.g. "web_search_tool_result") only carry Type and ID here.
+type AnthropicContent struct {
+	Type      string              `json:"type"`
+	Text      string              `json:"text,omitempty"`
+	Citations []AnthropicCitation `json:"citations,omitempty"`
+	ID        string              `json:"id,omitempty"`
+	Name      string              `json:"name,omitempty"`
+	Input     json.RawMessage     `json:"input,omitempty"`
+	Thinking  string              `json:"thinking,omitempty"`
+	Signature string              `json:"signature,omitempty"`
+	Data      string              `json:"data,omitempty"`
+}
+
+// AnthropicCitation points into a document with citations enabled. Char, page or
+// block indexes are set depending on the document type.
+type AnthropicCitation struct {
+	Type            string `json:"type"`
+	CitedText       string `json:"cited_text"`
+	DocumentIndex   int    `json:"document_index"`
+	DocumentTitle   string `json:"document_title,omitempty"`
+	StartCharIndex  int    `json:"start_char_index,omitempty"`
+	EndCharIndex    int    `json:"end_char_index,omitempty"`
+	StartPageNumber int    `json:"start_page_number,omitempty"`
+	EndPageNumber   int    `json:"end_page_number,omitempty"`
+	StartBlockIndex int    `json:"start_block_index,omitempty"`
+	EndBlockIndex   int    `json:"end_block_index,omitempty"`
+	URL             string `json:"url,omitempty"`
+	Title           string `json:"title,omitempty"`
+}
+
+type AnthropicUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// AnthropicEvent is one event of a streamed message.
+// https://docs.anthropic.com/en/docs/build-with-claude/streaming
+type AnthropicEvent struct {
+	Type         string             `json:"type"`
+	Index        int                `json:"index"`
+	Message      *AnthropicResponse `json:"message,omitempty"`
+	ContentBlock *AnthropicContent  `json:"content_block,omitempty"`
+	Delta        *AnthropicDelta    `json:"delta,omitempty"`
+	Usage        *AnthropicUsage    `json:"usage,omitempty"`
+	Error        *Error             `json:"error,omitempty"`
+}
+
+// AnthropicDelta is a content block delta or, for "message_delta", the stop reason.
+type AnthropicDelta struct {
+	Type         string             `json:"type,omitempty"`
+	Text         string             `json:"text,omitempty"`
+	PartialJSON  string             `json:"partial_json,omitempty"`
+	Thinking     string             `json:"thinking,omitempty"`
+	Signature    string             `json:"signature,omitempty"`
+	Citation     *AnthropicCitation `json:"citation,omitempty"`
+	StopReason   string             `json:"stop_reason,omitempty"`
+	StopSequence string             `json:"stop_sequence,omitempty"`
+}
+
+// Apply adds a stream event to the message, so after "message_stop" it equals the non-streamed response.
+func (r *AnthropicResponse) Apply(event AnthropicEvent) error {
+	switch event.Type {
+	case "message_start":
+		if event.Message != nil {
+			*r = *event.Message
+		}
+	case "content_block_start":
+		if event.ContentBlock == nil {
+			return fmt.Errorf("content block %d start without block", event.Index)
+		}
+		for len(r.Content) <= event.Index {
+			r.Content = append(r.Content, AnthropicContent{})
+		}
+		block := *event.ContentBlock
+		// tool_use and server_tool_use start with an empty input, it arrives as partial json
+		block.Input = nil
+		r.Content[event.Index] = block
+	case "content_block_delta":
+		if event.Delta == nil || event.Index >= len(r.Content) {
+			return fmt.Errorf("content block %d delta without started block", event.Index)
+		}
+		block := &r.Content[event.Index]
+		block.Text += event.Delta.Text
+		block.Thinking += event.Delta.Thinking
+		block.Input = append(block.Input, event.Delta.PartialJSON...)
+		if event.Delta.Signature != "" {
+			block.Signature = event.Delta.Signature
+		}
+		if event.Delta.Citation != nil {
+			block.Citations = append(block.Citations, *event.Delta.Citation)
+		}
+	case "content_block_stop":
+		if event.Index < len(r.Content) && strings.HasSuffix(r.Content[event.Index].Type, ANTHROPIC_BLOCK_TOOL_USE) && len(r.Content[event.Index].Input) == 0 {
+			r.Content[event.Index].Input = json.RawMessage("{}")
+		}
+	case "message_delta":
+		if event.Delta != nil {
+			r.StopReason = event.Delta.StopReason
+			r.StopSequence = event.Delta.StopSequence
+		}
+		if event.Usage != nil {
+			// message_delta usage is cumulative
+			r.Usage.OutputTokens = event.Usage.OutputTokens
+			if event.Usage.InputTokens > 0 {
+				r.Usage.InputTokens = event.Usage.InputTokens
+			}
+		}
+	}
+	return nil
+}
+
+// Text joins the text blocks.
+func (r *AnthropicResponse) Text() string {
+	var text strings.Builder
+	for _, block := range r.Content {
+		if block.Type == ANTHROPIC_BLOCK_TEXT {
+			text.WriteString(block.Text)
+		}
+	}
+	return text.String()
+}
+
+// ThinkingBlocks returns the thinking blocks with their signatures.
+func (r *AnthropicResponse) ThinkingBlocks() common.ThinkingBlocks {
+	var blocks common.ThinkingBlocks
+	for _, block := range r.Content {
+		if block.Type == common.THINKING_BLOCK_THINKING || block.Type == common.THINKING_BLOCK_REDACTED {
+			blocks = append(blocks, common.ThinkingBlock{
+				Type:      block.Type,
+				Thinking:  block.Thinking,
+				Signature: block.Signature,
+				Data:      block.Data,
+			})
+		}
+	}
+	return blocks
+}
+
+// ToolCalls returns the tool_use blocks in the shape of chat completion tool calls.
+func (r *AnthropicResponse) ToolCalls() (common.ToolCalls, error) {
+	toolCalls := make(common.ToolCalls, 0)
+	for _, block := range r.Content {
+		if block.Type != ANTHROPIC_BLOCK_TOOL_USE {
+			continue
+		}
+		var err error
+		toolCalls, err = appendToolCall(toolCalls, block.ID, block.Name, block.Input)
+		if err != nil {
+			return nil, fmt.Errorf("tool use %s: %w", block.ID, err)
+		}
+	}
+	return toolCalls, nil
+}
+
+// ToResponse converts to a chat completion response with a single choice. Citations are not carried over.
+func (r *AnthropicResponse) ToResponse() (Response, error) {
+	toolCalls, err := r.ToolCalls()
+	if err != nil {
+		return Response{}, err
+	}
+
+	finishReason := FINISH_REASON_STOP
+	switch r.StopReason {
+	case ANTHROPIC_STOP_TOOL_USE:
+		finishReason = FINISH_REASON_TOOL
+	case ANTHROPIC_STOP_MAX_TOKENS:
+		finishReason = FINISH_REASON_LENGTH
+	}
+
+	message := ResponseMessage{Content: r.Text(), ThinkingBlocks: r.ThinkingBlocks()}
+	for _, block := range message.ThinkingBlocks {
+		message.ReasoningContent += block.Thinking
+	}
+
+	res := chatCompletion(r.ID, r.Model, finishReason, message, toolCalls)
+	res.Usage = ResponseUsage{
+		PromptTokens:     r.Usage.InputTokens + r.Usage.CacheReadInputTokens + r.Usage.CacheCreationInputTokens,
+		CompletionTokens: r.Usage.OutputTokens,
+	}
+	res.Usage.TotalTokens = res.Usage.PromptTokens + res.Usage.CompletionTokens
+	res.Usage.PromptTokensDetails.CachedTokens = r.Usage.CacheReadInputTokens
+	res.Usage.PromptTokensDetails.CacheCreationTokens = r.Usage.CacheCreationInputTokens
+	return res, nil
+}
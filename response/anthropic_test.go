@@ -0,0 +1,77 @@
+package response_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func TestAnthropicResponse_ToResponse(t *testing.T) {
+	res := response.AnthropicResponse{
+		ID:    "msg-1",
+		Model: "claude-sonnet-4",
+		Content: []response.AnthropicContent{
+			{Type: "thinking", Thinking: "use the tool", Signature: "sig-1"},
+			{Type: "text", Text: "Checking."},
+			{Type: "tool_use", ID: "toolu-1", Name: "get_weather", Input: json.RawMessage(`{"city":"Riga","days":2}`)},
+		},
+		StopReason: response.ANTHROPIC_STOP_TOOL_USE,
+		Usage:      response.AnthropicUsage{InputTokens: 10, OutputTokens: 5, CacheReadInputTokens: 100, CacheCreationInputTokens: 20},
+	}
+
+	converted, err := res.ToResponse()
+	require.NoError(t, err)
+	choice := converted.Choice()
+	assert.Equal(t, response.FINISH_REASON_TOOL, choice.FinishReason)
+	assert.Equal(t, "Checking.", choice.Message.Content)
+	assert.Equal(t, "use the tool", choice.Message.ReasoningContent)
+	assert.Equal(t, common.ThinkingBlocks{{Type: "thinking", Thinking: "use the tool", Signature: "sig-1"}}, choice.Message.ThinkingBlocks)
+	assert.Equal(t, common.ToolCalls{{
+		ID:       "toolu-1",
+		Type:     "function",
+		Function: common.ToolCallFunction{Name: "get_weather", Arguments: common.Arguments{"city": "Riga", "days": 2}},
+	}}, choice.Message.ToolCalls)
+	assert.Equal(t, 130, converted.Usage.PromptTokens)
+	assert.Equal(t, 135, converted.Usage.TotalTokens)
+	assert.Equal(t, 100, converted.Usage.CacheReadTokens())
+	assert.Equal(t, 20, converted.Usage.CacheCreationTokens())
+
+	res.StopReason = response.ANTHROPIC_STOP_MAX_TOKENS
+	res.Content = res.Content[:2]
+	converted, err = res.ToResponse()
+	require.NoError(t, err)
+	assert.Equal(t, response.FINISH_REASON_LENGTH, converted.Choice().FinishReason)
+}
+
+func TestAnthropicResponse_Apply(t *testing.T) {
+	var res response.AnthropicResponse
+	assert.ErrorContains(t, res.Apply(response.AnthropicEvent{Type: "content_block_delta", Index: 0, Delta: &response.AnthropicDelta{Text: "x"}}), "without started block")
+
+	events := []response.AnthropicEvent{
+		{Type: "message_start", Message: &response.AnthropicResponse{ID: "msg-1"}},
+		{Type: "content_block_start", Index: 0, ContentBlock: &response.AnthropicContent{Type: "text"}},
+		{Type: "content_block_delta", Index: 0, Delta: &response.AnthropicDelta{Type: "citations_delta", Citation: &response.AnthropicCitation{CitedText: "blue"}}},
+		{Type: "content_block_delta", Index: 0, Delta: &response.AnthropicDelta{Type: "text_delta", Text: "Blue."}},
+		{Type: "content_block_start", Index: 1, ContentBlock: &response.AnthropicContent{Type: "tool_use", ID: "toolu-1", Input: json.RawMessage(`{}`)}},
+		{Type: "content_block_stop", Index: 1},
+		{Type: "content_block_start", Index: 2, ContentBlock: &response.AnthropicContent{Type: "server_tool_use", ID: "srvtoolu-1", Name: "web_search", Input: json.RawMessage(`{}`)}},
+		{Type: "content_block_delta", Index: 2, Delta: &response.AnthropicDelta{Type: "input_json_delta", PartialJSON: `{"query":`}},
+		{Type: "content_block_delta", Index: 2, Delta: &response.AnthropicDelta{Type: "input_json_delta", PartialJSON: `"sky color"}`}},
+		{Type: "content_block_stop", Index: 2},
+		{Type: "content_block_start", Index: 3, ContentBlock: &response.AnthropicContent{Type: "server_tool_use", ID: "srvtoolu-2", Input: json.RawMessage(`{}`)}},
+		{Type: "content_block_stop", Index: 3},
+	}
+	for _, event := range events {
+		require.NoError(t, res.Apply(event))
+	}
+	assert.Equal(t, "Blue.", res.Text())
+	assert.Equal(t, "blue", res.Content[0].Citations[0].CitedText)
+	assert.Equal(t, json.RawMessage(`{}`), res.Content[1].Input)
+	assert.JSONEq(t, `{"query":"sky color"}`, string(res.Content[2].Input))
+	assert.Equal(t, json.RawMessage(`{}`), res.Content[3].Input)
+}
@@ -0,0 +1,92 @@
+package response
+
+type File struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	Bytes     int64  `json:"bytes"`
+	CreatedAt int64  `json:"created_at"`
+	Filename  string `json:"filename"`
+	Purpose   string `json:"purpose"`
+	Status    string `json:"status,omitempty"`
+}
+
+type FileList struct {
+	Object  string `json:"object"`
+	Data    []File `json:"data"`
+	HasMore bool   `json:"has_more"`
+}
+
+type FileDeleted struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+const (
+	BATCH_STATUS_VALIDATING  = "validating"
+	BATCH_STATUS_FAILED      = "failed"
+	BATCH_STATUS_IN_PROGRESS = "in_progress"
+	BATCH_STATUS_FINALIZING  = "finalizing"
+	BATCH_STATUS_COMPLETED   = "completed"
+	BATCH_STATUS_EXPIRED     = "expired"
+	BATCH_STATUS_CANCELLING  = "cancelling"
+	BATCH_STATUS_CANCELLED   = "cancelled"
+)
+
+type Batch struct {
+	ID               string              `json:"id"`
+	Object           string              `json:"object"`
+	Endpoint         string              `json:"endpoint"`
+	Errors           *BatchErrors        `json:"errors,omitempty"`
+	InputFileID      string              `json:"input_file_id"`
+	CompletionWindow string              `json:"completion_window"`
+	Status           string              `json:"status"`
+	OutputFileID     string              `json:"output_file_id,omitempty"`
+	ErrorFileID      string              `json:"error_file_id,omitempty"`
+	CreatedAt        int64               `json:"created_at"`
+	InProgressAt     int64               `json:"in_progress_at,omitempty"`
+	ExpiresAt        int64               `json:"expires_at,omitempty"`
+	FinalizingAt     int64               `json:"finalizing_at,omitempty"`
+	CompletedAt      int64               `json:"completed_at,omitempty"`
+	FailedAt         int64               `json:"failed_at,omitempty"`
+	ExpiredAt        int64               `json:"expired_at,omitempty"`
+	CancellingAt     int64               `json:"cancelling_at,omitempty"`
+	CancelledAt      int64               `json:"cancelled_at,omitempty"`
+	RequestCounts    *BatchRequestCounts `json:"request_counts,omitempty"`
+	Metadata         map[string]string   `json:"metadata,omitempty"`
+}
+
+// Done reports whether the batch reached a final status.
+func (b Batch) Done() bool {
+	switch b.Status {
+	case BATCH_STATUS_COMPLETED, BATCH_STATUS_FAILED, BATCH_STATUS_EXPIRED, BATCH_STATUS_CANCELLED:
+		return true
+	}
+	return false
+}
+
+type BatchErrors struct {
+	Object string       `json:"object"`
+	Data   []BatchError `json:"data"`
+}
+
+type BatchError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Param   string `json:"param,omitempty"`
+	Line    int    `json:"line,omitempty"`
+}
+
+type BatchRequestCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+}
+
+type BatchList struct {
+	Object  string  `json:"object"`
+	Data    []Batch `json:"data"`
+	FirstID string  `json:"first_id,omitempty"`
+	LastID  string  `json:"last_id,omitempty"`
+	HasMore bool    `json:"has_more"`
+}
@@ -1,5 +1,13 @@
 package response
 
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
 type EmbeddingUsage struct {
 	PromptTokens int `json:"prompt_tokens"`
 	TotalTokens  int `json:"total_tokens"`
@@ -7,6 +15,38 @@ type EmbeddingUsage struct {
 
 type Embedding []float64
 
+// UnmarshalJSON accepts a float array or, for encoding_format base64,
+// a base64 string of little endian float32 values.
+func (e *Embedding) UnmarshalJSON(data []byte) error {
+	if len(data) == 0 || data[0] != '"' {
+		var floats []float64
+		if err := json.Unmarshal(data, &floats); err != nil {
+			return fmt.Errorf("error unmarshalling embedding: %w", err)
+		}
+		*e = floats
+		return nil
+	}
+
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return fmt.Errorf("error unmarshalling embedding: %w", err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("error decoding base64 embedding: %w", err)
+	}
+	if len(raw)%4 != 0 {
+		return fmt.Errorf("base64 embedding has %d bytes, not a multiple of 4", len(raw))
+	}
+
+	floats := make(Embedding, len(raw)/4)
+	for i := range floats {
+		floats[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(raw[i*4:])))
+	}
+	*e = floats
+	return nil
+}
+
 type EmbeddingData struct {
 	Object    string    `json:"object"`
 	Embedding Embedding `json:"embedding"`
@@ -27,3 +67,32 @@ func (e *Embedding) Float32() []float32 {
 	}
 	return float32s
 }
+
+// EmbeddingBatchResponse holds one result per input of a batched embeddings call.
+type EmbeddingBatchResponse struct {
+	Model string
+	// Data is in input order, Index is the input index. Failed inputs have no Embedding.
+	Data []EmbeddingData
+	// Errors is in input order, nil for embedded inputs.
+	Errors []error
+	// Usage is summed over all successful requests.
+	Usage EmbeddingUsage
+}
+
+// Err returns nil when every input was embedded, otherwise the first error.
+func (r EmbeddingBatchResponse) Err() error {
+	failed := 0
+	var first error
+	for _, err := range r.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d embeddings failed: %w", failed, len(r.Errors), first)
+}
@@ -0,0 +1,52 @@
+package response_test
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func base64Floats(values ...float32) string {
+	raw := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(raw[i*4:], math.Float32bits(v))
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestEmbedding_UnmarshalJSON(t *testing.T) {
+	t.Run("float", func(t *testing.T) {
+		var data response.EmbeddingData
+		require.NoError(t, json.Unmarshal([]byte(`{"object":"embedding","index":0,"embedding":[0.5,-0.25]}`), &data))
+		assert.Equal(t, response.Embedding{0.5, -0.25}, data.Embedding)
+	})
+
+	t.Run("base64", func(t *testing.T) {
+		var data response.EmbeddingData
+		payload := `{"object":"embedding","index":1,"embedding":"` + base64Floats(0.1, -2.5, 3) + `"}`
+		require.NoError(t, json.Unmarshal([]byte(payload), &data))
+		assert.Equal(t, 1, data.Index)
+		assert.Equal(t, []float32{0.1, -2.5, 3}, data.Embedding.Float32())
+	})
+
+	t.Run("null", func(t *testing.T) {
+		var data response.EmbeddingData
+		require.NoError(t, json.Unmarshal([]byte(`{"embedding":null}`), &data))
+		assert.Nil(t, data.Embedding)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var e response.Embedding
+		assert.ErrorContains(t, json.Unmarshal([]byte(`"***"`), &e), "error decoding base64 embedding")
+		assert.ErrorContains(t, json.Unmarshal([]byte(`"AAAA AA=="`), &e), "error decoding base64 embedding")
+		assert.ErrorContains(t, json.Unmarshal([]byte(`"AAA="`), &e), "not a multiple of 4")
+		assert.Error(t, json.Unmarshal([]byte(`{"a":1}`), &e))
+	})
+}
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ImageResponse struct {
+	Created int64       `json:"created"`
+	Data    []ImageData `json:"data"`
+	Usage   *ImageUsage `json:"usage,omitempty"`
+}
+
+type ImageData struct {
+	URL           string `json:"url,omitempty"`
+	B64JSON       string `json:"b64_json,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
+
+type ImageUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// Image saved image file location
+type Image struct {
+	Name      string
+	Directory string
+	Full      string
+	Extension string
+}
+
+// Bytes decodes the b64_json payload.
+func (d ImageData) Bytes() ([]byte, error) {
+	if d.B64JSON == "" {
+		return nil, fmt.Errorf("image has no b64_json data (url: %q)", d.URL)
+	}
+	data, err := base64.StdEncoding.DecodeString(d.B64JSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode b64_json image: %w", err)
+	}
+	return data, nil
+}
+
+// Save writes the b64_json image into dir (os.TempDir() when empty) with a random file name.
+func (d ImageData) Save(dir string) (Image, error) {
+	data, err := d.Bytes()
+	if err != nil {
+		return Image{}, err
+	}
+
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	extension := imageExtension(data)
+	random := uuid.Must(uuid.NewRandom()).String()
+	fileName := fmt.Sprintf("image_%s.%s", random, extension)
+
+	fullFilePath := filepath.Join(dir, fileName)
+	err = os.WriteFile(fullFilePath, data, 0o644)
+	if err != nil {
+		_ = os.Remove(fullFilePath)
+		return Image{}, fmt.Errorf("failed to write image data to file %q: %w", fullFilePath, err)
+	}
+
+	return Image{
+		Full:      fullFilePath,
+		Name:      fileName,
+		Directory: dir,
+		Extension: extension,
+	}, nil
+}
+
+// SaveAll saves every b64_json image in the response.
+func (r ImageResponse) SaveAll(dir string) ([]Image, error) {
+	images := make([]Image, 0, len(r.Data))
+	for i, data := range r.Data {
+		img, err := data.Save(dir)
+		if err != nil {
+			return images, fmt.Errorf("failed to save image %d: %w", i, err)
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+func imageExtension(data []byte) string {
+	switch mimeType := http.DetectContentType(data); mimeType {
+	case "image/jpeg":
+		return "jpg"
+	case "image/png", "image/gif", "image/webp", "image/bmp":
+		return strings.TrimPrefix(mimeType, "image/")
+	}
+	return "png"
+}
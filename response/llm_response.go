@@ -16,6 +16,7 @@ type ResponseChoice struct {
 	Index        int              `json:"index"`
 	FinishReason FinishReasonType `json:"finish_reason"`
 	Message      ResponseMessage  `json:"message"`
+	Logprobs     *ChoiceLogprobs  `json:"logprobs,omitempty"`
 }
 
 type ResponseChoices []ResponseChoice
@@ -25,6 +26,8 @@ type ResponseMessage struct {
 	ReasoningContent string           `json:"reasoning_content"`
 	Role             string           `json:"role"`
 	ToolCalls        common.ToolCalls `json:"tool_calls,omitempty"`
+	// ThinkingBlocks holds Anthropic extended thinking blocks with their signatures.
+	ThinkingBlocks common.ThinkingBlocks `json:"thinking_blocks,omitempty"`
 }
 
 func (rm *ResponseMessage) IsEmpty() bool {
@@ -73,6 +76,8 @@ type Response struct {
 	SystemFingerprint string          `json:"system_fingerprint"`
 	Choices           ResponseChoices `json:"choices"`
 	Usage             ResponseUsage   `json:"usage"`
+	// FromCache is set when the response was served by cache.Completions instead of the API.
+	FromCache bool `json:"-"`
 }
 
 func (r *Response) Choice() ResponseChoice {
@@ -124,3 +129,36 @@ type TokenCounterResponse struct {
 	RequestModel  string  `json:"request_model"`
 	TokenizerType string  `json:"tokenizer_type"`
 }
+
+// appendToolCall adds a function call of the responses or messages api as a chat completion tool call.
+func appendToolCall(toolCalls common.ToolCalls, id, name string, arguments []byte) (common.ToolCalls, error) {
+	args := make(common.Arguments)
+	if len(arguments) > 0 {
+		if err := args.UnmarshalJSON(arguments); err != nil {
+			return nil, err
+		}
+	}
+	return append(toolCalls, common.ToolCall{
+		ID:    id,
+		Type:  "function",
+		Index: len(toolCalls),
+		Function: common.ToolCallFunction{
+			Name:      name,
+			Arguments: args,
+		},
+	}), nil
+}
+
+// chatCompletion returns a chat completion response with message as its single choice, usage is left to the caller.
+func chatCompletion(id string, model models.ModelID, finishReason FinishReasonType, message ResponseMessage, toolCalls common.ToolCalls) Response {
+	message.Role = "assistant"
+	if len(toolCalls) > 0 {
+		message.ToolCalls = toolCalls
+	}
+	return Response{
+		ID:      id,
+		Model:   model,
+		Object:  "chat.completion",
+		Choices: ResponseChoices{{FinishReason: finishReason, Message: message}},
+	}
+}
@@ -1,6 +1,7 @@
 package response_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -317,3 +318,32 @@ func Test_SetText_Unit(t *testing.T) {
 		})
 	}
 }
+
+func Test_ThinkingBlocks_Unmarshal_Unit(t *testing.T) {
+	jsonStr := `{
+		"choices": [{
+			"index": 0,
+			"finish_reason": "tool_calls",
+			"message": {
+				"role": "assistant",
+				"content": null,
+				"reasoning_content": "Need the time.",
+				"thinking_blocks": [
+					{"type": "thinking", "thinking": "Need the time.", "signature": "EqoBCkgIARAB"},
+					{"type": "redacted_thinking", "data": "EmwKAhgBEgy3va"}
+				]
+			}
+		}]
+	}`
+
+	r := response.Response{}
+	err := json.Unmarshal([]byte(jsonStr), &r)
+	assert.NoError(t, err)
+
+	blocks := r.Message().ThinkingBlocks
+	assert.Len(t, blocks, 2)
+	assert.Equal(t, "thinking", blocks[0].Type)
+	assert.Equal(t, "EqoBCkgIARAB", blocks[0].Signature)
+	assert.Equal(t, "redacted_thinking", blocks[1].Type)
+	assert.Equal(t, "EmwKAhgBEgy3va", blocks[1].Data)
+}
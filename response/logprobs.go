@@ -0,0 +1,97 @@
+package response
+
+import (
+	"math"
+	"strings"
+)
+
+// "logprobs": {
+//   "content": [
+//     {"token": "Yes", "logprob": -0.0001, "bytes": [89, 101, 115],
+//      "top_logprobs": [{"token": "Yes", "logprob": -0.0001, "bytes": [89, 101, 115]}]}
+//   ]
+// }
+
+type ChoiceLogprobs struct {
+	Content []TokenLogprob `json:"content"`
+	Refusal []TokenLogprob `json:"refusal,omitempty"`
+}
+
+type TokenLogprob struct {
+	Token       string       `json:"token"`
+	Logprob     float64      `json:"logprob"`
+	Bytes       []int        `json:"bytes,omitempty"`
+	TopLogprobs []TopLogprob `json:"top_logprobs,omitempty"`
+}
+
+type TopLogprob struct {
+	Token   string  `json:"token"`
+	Logprob float64 `json:"logprob"`
+	Bytes   []int   `json:"bytes,omitempty"`
+}
+
+// Prob converts the log probability to a linear probability.
+func (t TokenLogprob) Prob() float64 {
+	return math.Exp(t.Logprob)
+}
+
+// MeanLogprob returns the average token logprob of the choice content.
+// The second value is false when the choice has no logprobs.
+func (c ResponseChoice) MeanLogprob() (float64, bool) {
+	if c.Logprobs == nil || len(c.Logprobs.Content) == 0 {
+		return 0, false
+	}
+
+	sum := 0.0
+	for _, token := range c.Logprobs.Content {
+		sum += token.Logprob
+	}
+	return sum / float64(len(c.Logprobs.Content)), true
+}
+
+// BestByMeanLogprob returns the choice with the highest mean token logprob.
+// Choices without logprobs are ignored; the second value is false when none have them.
+func (rc ResponseChoices) BestByMeanLogprob() (ResponseChoice, bool) {
+	var best ResponseChoice
+	bestScore, found := math.Inf(-1), false
+	for _, choice := range rc {
+		score, ok := choice.MeanLogprob()
+		if !ok || score <= bestScore {
+			continue
+		}
+		best, bestScore, found = choice, score, true
+	}
+	return best, found
+}
+
+// MajorityVote groups choices by key and returns the first choice of the largest group
+// together with the group size. With a nil key choices are grouped by trimmed content.
+// Ties go to the group that appeared first.
+func (rc ResponseChoices) MajorityVote(key func(ResponseChoice) string) (ResponseChoice, int) {
+	if key == nil {
+		key = func(c ResponseChoice) string {
+			return strings.TrimSpace(c.Message.Content)
+		}
+	}
+
+	counts := make(map[string]int, len(rc))
+	first := make(map[string]ResponseChoice, len(rc))
+	order := make([]string, 0, len(rc))
+	for _, choice := range rc {
+		k := key(choice)
+		if _, ok := first[k]; !ok {
+			first[k] = choice
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+
+	var winner ResponseChoice
+	votes := 0
+	for _, k := range order {
+		if counts[k] > votes {
+			winner, votes = first[k], counts[k]
+		}
+	}
+	return winner, votes
+}
@@ -0,0 +1,119 @@
+package response_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func Test_Logprobs_Unmarshal_Unit(t *testing.T) {
+	jsonStr := `{
+		"id": "chatcmpl-1",
+		"object": "chat.completion",
+		"choices": [
+			{
+				"index": 0,
+				"finish_reason": "stop",
+				"message": {"role": "assistant", "content": "Yes"},
+				"logprobs": {
+					"content": [
+						{"token": "Yes", "logprob": -0.25, "bytes": [89, 101, 115],
+						 "top_logprobs": [{"token": "Yes", "logprob": -0.25}, {"token": "No", "logprob": -1.6}]}
+					]
+				}
+			},
+			{
+				"index": 1,
+				"finish_reason": "stop",
+				"message": {"role": "assistant", "content": "No"},
+				"logprobs": null
+			}
+		]
+	}`
+
+	var r response.Response
+	require.NoError(t, json.Unmarshal([]byte(jsonStr), &r))
+	require.Len(t, r.Choices, 2)
+
+	lp := r.Choices[0].Logprobs
+	require.NotNil(t, lp)
+	require.Len(t, lp.Content, 1)
+	assert.Equal(t, "Yes", lp.Content[0].Token)
+	assert.Equal(t, -0.25, lp.Content[0].Logprob)
+	assert.Equal(t, []int{89, 101, 115}, lp.Content[0].Bytes)
+	assert.Len(t, lp.Content[0].TopLogprobs, 2)
+	assert.InDelta(t, 0.7788, lp.Content[0].Prob(), 0.0001)
+	assert.Nil(t, r.Choices[1].Logprobs)
+}
+
+func logprobChoice(index int, content string, logprobs ...float64) response.ResponseChoice {
+	choice := response.ResponseChoice{
+		Index:   index,
+		Message: response.ResponseMessage{Role: "assistant", Content: content},
+	}
+	if len(logprobs) > 0 {
+		choice.Logprobs = &response.ChoiceLogprobs{}
+		for _, lp := range logprobs {
+			choice.Logprobs.Content = append(choice.Logprobs.Content, response.TokenLogprob{Logprob: lp})
+		}
+	}
+	return choice
+}
+
+func Test_MeanLogprob_Unit(t *testing.T) {
+	mean, ok := logprobChoice(0, "a", -1, -2, -3).MeanLogprob()
+	assert.True(t, ok)
+	assert.Equal(t, -2.0, mean)
+
+	_, ok = logprobChoice(0, "a").MeanLogprob()
+	assert.False(t, ok)
+}
+
+func Test_BestByMeanLogprob_Unit(t *testing.T) {
+	choices := response.ResponseChoices{
+		logprobChoice(0, "low", -2, -2),
+		logprobChoice(1, "none"),
+		logprobChoice(2, "high", -0.1, -0.3),
+		logprobChoice(3, "mid", -1),
+	}
+
+	best, ok := choices.BestByMeanLogprob()
+	assert.True(t, ok)
+	assert.Equal(t, 2, best.Index)
+
+	_, ok = response.ResponseChoices{logprobChoice(0, "none")}.BestByMeanLogprob()
+	assert.False(t, ok)
+}
+
+func Test_MajorityVote_Unit(t *testing.T) {
+	choices := response.ResponseChoices{
+		logprobChoice(0, "Paris"),
+		logprobChoice(1, " paris "),
+		logprobChoice(2, "Lyon"),
+		logprobChoice(3, "Paris\n"),
+	}
+
+	winner, votes := choices.MajorityVote(nil)
+	assert.Equal(t, 0, winner.Index)
+	assert.Equal(t, 2, votes)
+
+	winner, votes = choices.MajorityVote(func(c response.ResponseChoice) string {
+		return strings.ToLower(strings.TrimSpace(c.Message.Content))
+	})
+	assert.Equal(t, 0, winner.Index)
+	assert.Equal(t, 3, votes)
+
+	tie := response.ResponseChoices{logprobChoice(0, "a"), logprobChoice(1, "b")}
+	winner, votes = tie.MajorityVote(nil)
+	assert.Equal(t, 0, winner.Index)
+	assert.Equal(t, 1, votes)
+
+	winner, votes = response.ResponseChoices{}.MajorityVote(nil)
+	assert.Equal(t, response.ResponseChoice{}, winner)
+	assert.Equal(t, 0, votes)
+}
@@ -0,0 +1,65 @@
+package response
+
+type ModerationCategory string
+
+const (
+	MODERATION_HARASSMENT             ModerationCategory = "harassment"
+	MODERATION_HARASSMENT_THREATENING ModerationCategory = "harassment/threatening"
+	MODERATION_HATE                   ModerationCategory = "hate"
+	MODERATION_HATE_THREATENING       ModerationCategory = "hate/threatening"
+	MODERATION_ILLICIT                ModerationCategory = "illicit"
+	MODERATION_ILLICIT_VIOLENT        ModerationCategory = "illicit/violent"
+	MODERATION_SELF_HARM              ModerationCategory = "self-harm"
+	MODERATION_SELF_HARM_INTENT       ModerationCategory = "self-harm/intent"
+	MODERATION_SELF_HARM_INSTRUCTIONS ModerationCategory = "self-harm/instructions"
+	MODERATION_SEXUAL                 ModerationCategory = "sexual"
+	MODERATION_SEXUAL_MINORS          ModerationCategory = "sexual/minors"
+	MODERATION_VIOLENCE               ModerationCategory = "violence"
+	MODERATION_VIOLENCE_GRAPHIC       ModerationCategory = "violence/graphic"
+)
+
+type ModerationResponse struct {
+	ID      string             `json:"id"`
+	Model   string             `json:"model"`
+	Results []ModerationResult `json:"results"`
+}
+
+// ModerationResult holds the flags and scores of one input. Providers may report
+// categories beyond the constants above.
+type ModerationResult struct {
+	Flagged        bool                           `json:"flagged"`
+	Categories     map[ModerationCategory]bool    `json:"categories"`
+	CategoryScores map[ModerationCategory]float64 `json:"category_scores"`
+	// CategoryAppliedInputTypes tells which input types ("text", "image") a category was detected in.
+	CategoryAppliedInputTypes map[ModerationCategory][]string `json:"category_applied_input_types,omitempty"`
+}
+
+// Flagged reports whether any result was flagged.
+func (r ModerationResponse) Flagged() bool {
+	for _, result := range r.Results {
+		if result.Flagged {
+			return true
+		}
+	}
+	return false
+}
+
+// Exceeding returns the scores of the categories that reach their threshold.
+// An empty thresholds map returns the flagged categories instead.
+func (r ModerationResult) Exceeding(thresholds map[ModerationCategory]float64) map[ModerationCategory]float64 {
+	exceeding := make(map[ModerationCategory]float64)
+	if len(thresholds) == 0 {
+		for category, flagged := range r.Categories {
+			if flagged {
+				exceeding[category] = r.CategoryScores[category]
+			}
+		}
+		return exceeding
+	}
+	for category, threshold := range thresholds {
+		if score, ok := r.CategoryScores[category]; ok && score >= threshold {
+			exceeding[category] = score
+		}
+	}
+	return exceeding
+}
@@ -0,0 +1,69 @@
+package response
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+type RerankResponse struct {
+	ID string `json:"id"`
+	// Results are ordered by relevance, best first.
+	Results []RerankResult `json:"results"`
+	Meta    *RerankMeta    `json:"meta,omitempty"`
+}
+
+type RerankResult struct {
+	// Index of the document in the request.
+	Index          int     `json:"index"`
+	RelevanceScore float64 `json:"relevance_score"`
+	// Document is set when the request had ReturnDocuments.
+	Document *RerankDocument `json:"document,omitempty"`
+}
+
+type RerankDocument struct {
+	Text string `json:"text"`
+}
+
+// UnmarshalJSON accepts {"text": "..."} and plain strings, providers differ.
+func (d *RerankDocument) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &d.Text)
+	}
+	type alias RerankDocument
+	return json.Unmarshal(data, (*alias)(d))
+}
+
+type RerankMeta struct {
+	BilledUnits *RerankBilledUnits `json:"billed_units,omitempty"`
+}
+
+type RerankBilledUnits struct {
+	SearchUnits int `json:"search_units,omitempty"`
+}
+
+// Ranked is an item of the caller with its rerank score.
+type Ranked[T any] struct {
+	Item T
+	// Index of the item in the reranked slice.
+	Index int
+	Score float64
+}
+
+// Rank orders items by the scores of results, best first. items must be the slice,
+// or a slice in the same order, as the documents sent. Items without a result (see TopN)
+// are left out.
+func Rank[T any](items []T, results []RerankResult) ([]Ranked[T], error) {
+	ranked := make([]Ranked[T], 0, len(results))
+	for _, result := range results {
+		if result.Index < 0 || result.Index >= len(items) {
+			return nil, fmt.Errorf("rerank result index %d out of range for %d items", result.Index, len(items))
+		}
+		ranked = append(ranked, Ranked[T]{Item: items[result.Index], Index: result.Index, Score: result.RelevanceScore})
+	}
+	slices.SortStableFunc(ranked, func(a, b Ranked[T]) int {
+		return cmp.Compare(b.Score, a.Score)
+	})
+	return ranked, nil
+}
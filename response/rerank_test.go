@@ -0,0 +1,33 @@
+package response_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func TestRank(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	// results may arrive unordered, equal scores keep the result order
+	results := []response.RerankResult{
+		{Index: 3, RelevanceScore: 0.2},
+		{Index: 0, RelevanceScore: 0.9},
+		{Index: 2, RelevanceScore: 0.5},
+		{Index: 1, RelevanceScore: 0.5},
+	}
+
+	ranked, err := response.Rank(items, results)
+	require.NoError(t, err)
+	assert.Equal(t, []response.Ranked[string]{
+		{Item: "a", Index: 0, Score: 0.9},
+		{Item: "c", Index: 2, Score: 0.5},
+		{Item: "b", Index: 1, Score: 0.5},
+		{Item: "d", Index: 3, Score: 0.2},
+	}, ranked)
+
+	_, err = response.Rank(items[:2], results)
+	assert.ErrorContains(t, err, "rerank result index 3 out of range for 2 items")
+}
@@ -0,0 +1,192 @@
+package response
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/models"
+)
+
+const (
+	RESPONSES_STATUS_COMPLETED   = "completed"
+	RESPONSES_STATUS_IN_PROGRESS = "in_progress"
+	RESPONSES_STATUS_INCOMPLETE  = "incomplete"
+	RESPONSES_STATUS_FAILED      = "failed"
+
+	RESPONSES_OUTPUT_MESSAGE       = "message"
+	RESPONSES_OUTPUT_FUNCTION_CALL = "function_call"
+	RESPONSES_OUTPUT_REASONING     = "reasoning"
+
+	RESPONSES_EVENT_CREATED           = "response.created"
+	RESPONSES_EVENT_OUTPUT_TEXT_DELTA = "response.output_text.delta"
+	RESPONSES_EVENT_ARGUMENTS_DELTA   = "response.function_call_arguments.delta"
+	RESPONSES_EVENT_ITEM_DONE         = "response.output_item.done"
+	RESPONSES_EVENT_COMPLETED         = "response.completed"
+	RESPONSES_EVENT_INCOMPLETE        = "response.incomplete"
+	RESPONSES_EVENT_FAILED            = "response.failed"
+	RESPONSES_EVENT_ERROR             = "error"
+)
+
+const FINISH_REASON_LENGTH FinishReasonType = "length"
+
+// ResponsesResponse is the /v1/responses result.
+// https://platform.openai.com/docs/api-reference/responses/object
+type ResponsesResponse struct {
+	ID                 string                      `json:"id"`
+	Object             string                      `json:"object"`
+	CreatedAt          int64                       `json:"created_at"`
+	Status             string                      `json:"status"`
+	Model              models.ModelID              `json:"model"`
+	Output             []ResponsesOutputItem       `json:"output"`
+	Usage              ResponsesUsage              `json:"usage"`
+	Error              *Error                      `json:"error,omitempty"`
+	IncompleteDetails  *ResponsesIncompleteDetails `json:"incomplete_details,omitempty"`
+	PreviousResponseID string                      `json:"previous_response_id,omitempty"`
+}
+
+// ResponsesOutputItem is a message, function call, reasoning summary or built-in tool call.
+// Built-in tool calls (e.g. "web_search_call") only carry Type, ID and Status here.
+type ResponsesOutputItem struct {
+	Type      string                   `json:"type"`
+	ID        string                   `json:"id"`
+	Status    string                   `json:"status,omitempty"`
+	Role      string                   `json:"role,omitempty"`
+	Content   []ResponsesOutputContent `json:"content,omitempty"`
+	CallID    string                   `json:"call_id,omitempty"`
+	Name      string                   `json:"name,omitempty"`
+	Arguments string                   `json:"arguments,omitempty"`
+	Summary   []ResponsesSummary       `json:"summary,omitempty"`
+}
+
+// ResponsesOutputContent is "output_text" or "refusal".
+type ResponsesOutputContent struct {
+	Type        string                `json:"type"`
+	Text        string                `json:"text,omitempty"`
+	Refusal     string                `json:"refusal,omitempty"`
+	Annotations []ResponsesAnnotation `json:"annotations,omitempty"`
+}
+
+// ResponsesAnnotation is a citation of the web or file search tools.
+type ResponsesAnnotation struct {
+	Type       string `json:"type"`
+	URL        string `json:"url,omitempty"`
+	Title      string `json:"title,omitempty"`
+	FileID     string `json:"file_id,omitempty"`
+	Filename   string `json:"filename,omitempty"`
+	StartIndex int    `json:"start_index,omitempty"`
+	EndIndex   int    `json:"end_index,omitempty"`
+}
+
+type ResponsesSummary struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type ResponsesUsage struct {
+	InputTokens        int `json:"input_tokens"`
+	OutputTokens       int `json:"output_tokens"`
+	TotalTokens        int `json:"total_tokens"`
+	InputTokensDetails struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"input_tokens_details"`
+	OutputTokensDetails struct {
+		ReasoningTokens int `json:"reasoning_tokens"`
+	} `json:"output_tokens_details"`
+}
+
+type ResponsesIncompleteDetails struct {
+	// Reason is e.g. "max_output_tokens" or "content_filter".
+	Reason string `json:"reason"`
+}
+
+// ResponsesEvent is one event of a streamed response. Response is set for the
+// lifecycle events, Item for output item events and Delta for text and argument deltas.
+type ResponsesEvent struct {
+	Type           string               `json:"type"`
+	SequenceNumber int                  `json:"sequence_number"`
+	ItemID         string               `json:"item_id,omitempty"`
+	OutputIndex    int                  `json:"output_index"`
+	ContentIndex   int                  `json:"content_index"`
+	Delta          string               `json:"delta,omitempty"`
+	Item           *ResponsesOutputItem `json:"item,omitempty"`
+	Response       *ResponsesResponse   `json:"response,omitempty"`
+	// Code and Message are set for RESPONSES_EVENT_ERROR.
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// OutputText joins the text of all output messages.
+func (r *ResponsesResponse) OutputText() string {
+	var text strings.Builder
+	for _, item := range r.Output {
+		if item.Type != RESPONSES_OUTPUT_MESSAGE {
+			continue
+		}
+		for _, content := range item.Content {
+			if content.Type == "output_text" {
+				text.WriteString(content.Text)
+			}
+		}
+	}
+	return text.String()
+}
+
+// ReasoningText joins the reasoning summaries, they are only returned when requested.
+func (r *ResponsesResponse) ReasoningText() string {
+	summaries := make([]string, 0)
+	for _, item := range r.Output {
+		if item.Type != RESPONSES_OUTPUT_REASONING {
+			continue
+		}
+		for _, summary := range item.Summary {
+			summaries = append(summaries, summary.Text)
+		}
+	}
+	return strings.Join(summaries, "\n")
+}
+
+// ToolCalls returns the function calls in the shape of chat completion tool calls,
+// so they can be executed and answered with request.ToolCallMessage.
+func (r *ResponsesResponse) ToolCalls() (common.ToolCalls, error) {
+	toolCalls := make(common.ToolCalls, 0)
+	for _, item := range r.Output {
+		if item.Type != RESPONSES_OUTPUT_FUNCTION_CALL {
+			continue
+		}
+		var err error
+		toolCalls, err = appendToolCall(toolCalls, item.CallID, item.Name, []byte(item.Arguments))
+		if err != nil {
+			return nil, fmt.Errorf("function call %s: %w", item.CallID, err)
+		}
+	}
+	return toolCalls, nil
+}
+
+// ToResponse converts to a chat completion response with a single choice.
+func (r *ResponsesResponse) ToResponse() (Response, error) {
+	toolCalls, err := r.ToolCalls()
+	if err != nil {
+		return Response{}, err
+	}
+
+	finishReason := FINISH_REASON_STOP
+	switch {
+	case len(toolCalls) > 0:
+		finishReason = FINISH_REASON_TOOL
+	case r.IncompleteDetails != nil && r.IncompleteDetails.Reason == "max_output_tokens":
+		finishReason = FINISH_REASON_LENGTH
+	}
+
+	message := ResponseMessage{Content: r.OutputText(), ReasoningContent: r.ReasoningText()}
+	res := chatCompletion(r.ID, r.Model, finishReason, message, toolCalls)
+	res.Created = int(r.CreatedAt)
+	res.Usage = ResponseUsage{
+		PromptTokens:     r.Usage.InputTokens,
+		CompletionTokens: r.Usage.OutputTokens,
+		TotalTokens:      r.Usage.TotalTokens,
+	}
+	res.Usage.PromptTokensDetails.CachedTokens = r.Usage.InputTokensDetails.CachedTokens
+	res.Usage.CompletionTokensDetails.ReasoningTokens = r.Usage.OutputTokensDetails.ReasoningTokens
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package response_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/common"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+func TestResponsesResponse_ToResponse(t *testing.T) {
+	var res response.ResponsesResponse
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id":"resp-1","object":"response","created_at":1700000000,"status":"completed","model":"gpt-5-mini",
+		"output":[
+			{"type":"reasoning","id":"rs-1","summary":[{"type":"summary_text","text":"look up weather"}]},
+			{"type":"web_search_call","id":"ws-1","status":"completed"},
+			{"type":"message","id":"msg-1","role":"assistant","content":[
+				{"type":"output_text","text":"Checking ","annotations":[{"type":"url_citation","url":"https://example.com","title":"Example"}]},
+				{"type":"output_text","text":"now."}
+			]},
+			{"type":"function_call","id":"fc-1","call_id":"call-1","name":"get_weather","arguments":"{\"city\":\"Riga\",\"days\":2}"}
+		],
+		"usage":{"input_tokens":10,"output_tokens":5,"total_tokens":15,
+			"input_tokens_details":{"cached_tokens":4},"output_tokens_details":{"reasoning_tokens":3}}
+	}`), &res))
+
+	assert.Equal(t, "Checking now.", res.OutputText())
+	assert.Equal(t, "url_citation", res.Output[2].Content[0].Annotations[0].Type)
+
+	converted, err := res.ToResponse()
+	require.NoError(t, err)
+	choice := converted.Choice()
+	assert.Equal(t, response.FINISH_REASON_TOOL, choice.FinishReason)
+	assert.Equal(t, "Checking now.", choice.Message.Content)
+	assert.Equal(t, "look up weather", choice.Message.ReasoningContent)
+	assert.Equal(t, common.ToolCalls{{
+		ID:   "call-1",
+		Type: "function",
+		Function: common.ToolCallFunction{
+			Name:      "get_weather",
+			Arguments: common.Arguments{"city": "Riga", "days": 2},
+		},
+	}}, choice.Message.ToolCalls)
+	assert.Equal(t, 10, converted.Usage.PromptTokens)
+	assert.Equal(t, 5, converted.Usage.CompletionTokens)
+	assert.Equal(t, 4, converted.Usage.CacheReadTokens())
+	assert.Equal(t, 3, converted.Usage.CompletionTokensDetails.ReasoningTokens)
+}
+
+func TestResponsesResponse_Incomplete(t *testing.T) {
+	res := response.ResponsesResponse{
+		Status:            response.RESPONSES_STATUS_INCOMPLETE,
+		IncompleteDetails: &response.ResponsesIncompleteDetails{Reason: "max_output_tokens"},
+	}
+	converted, err := res.ToResponse()
+	require.NoError(t, err)
+	assert.Equal(t, response.FINISH_REASON_LENGTH, converted.Choice().FinishReason)
+
+	res.Output = []response.ResponsesOutputItem{{Type: response.RESPONSES_OUTPUT_FUNCTION_CALL, CallID: "call-1", Arguments: "{"}}
+	_, err = res.ToResponse()
+	assert.ErrorContains(t, err, "function call call-1")
+}
@@ -0,0 +1,74 @@
+// Package sse reads server-sent event streams of the streaming endpoints.
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Event is one dispatched event. Name is the "event:" field, empty when not sent.
+type Event struct {
+	Name string
+	Data []byte
+}
+
+// Read calls fn for every event with data until the stream ends, fn returns an error
+// or the OpenAI style "[DONE]" data arrives. Lines have no size limit.
+func Read(r io.Reader, fn func(Event) error) error {
+	reader := bufio.NewReader(r)
+	var name string
+	var data bytes.Buffer
+
+	dispatch := func() (bool, error) {
+		defer func() {
+			name = ""
+			data.Reset()
+		}()
+		if data.Len() == 0 {
+			return false, nil
+		}
+		if string(bytes.TrimSpace(data.Bytes())) == "[DONE]" {
+			return true, nil
+		}
+		return false, fn(Event{Name: name, Data: bytes.Clone(data.Bytes())})
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read event stream: %w", err)
+		}
+		eof := err != nil
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			if done, err := dispatch(); err != nil || done {
+				return err
+			}
+		case strings.HasPrefix(line, ":"):
+			// comment, e.g. keep-alive
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "event":
+				name = value
+			case "data":
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(value)
+			}
+		}
+
+		if eof {
+			_, err := dispatch()
+			return err
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sse_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/sse"
+)
+
+func TestRead(t *testing.T) {
+	stream := ": keep-alive\r\n" +
+		"event: response.created\r\n" +
+		"data: {\"a\":1}\r\n\r\n" +
+		"data: line one\n" +
+		"data: line two\n\n" +
+		"id: 7\n\n" +
+		"data: [DONE]\n\n" +
+		"data: after done\n\n"
+
+	events := make([]sse.Event, 0)
+	err := sse.Read(strings.NewReader(stream), func(event sse.Event) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []sse.Event{
+		{Name: "response.created", Data: []byte(`{"a":1}`)},
+		{Data: []byte("line one\nline two")},
+	}, events)
+}
+
+func TestRead_LastEventWithoutBlankLine(t *testing.T) {
+	var got []string
+	err := sse.Read(strings.NewReader("event: done\ndata: x"), func(event sse.Event) error {
+		got = append(got, event.Name+"="+string(event.Data))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"done=x"}, got)
+}
+
+func TestRead_StopsOnError(t *testing.T) {
+	calls := 0
+	err := sse.Read(strings.NewReader("data: 1\n\ndata: 2\n\n"), func(sse.Event) error {
+		calls++
+		return errors.New("stop")
+	})
+	assert.EqualError(t, err, "stop")
+	assert.Equal(t, 1, calls)
+}
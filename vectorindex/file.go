@@ -0,0 +1,231 @@
+package vectorindex
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"slices"
+)
+
+// File layout, all integers little endian:
+//
+//	magic "GLVI", version uint8, metric (uvarint length + bytes), dimensions uint32, count uint32
+//	per item: id, metadata count uvarint, metadata keys and values, dimensions * float32
+//
+// Strings are written as uvarint length + bytes.
+const (
+	fileMagic   = "GLVI"
+	fileVersion = 1
+
+	maxStringSize = 1 << 20
+	maxDimensions = 1 << 16
+)
+
+// Save writes the index in a compact binary format.
+func (i *Index) Save(w io.Writer) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	e := encoder{w: bw}
+	e.bytes([]byte(fileMagic))
+	e.bytes([]byte{fileVersion})
+	e.string(string(i.metric))
+	e.uint32(uint32(i.dims))
+	e.uint32(uint32(len(i.items)))
+
+	for _, item := range i.items {
+		e.string(item.ID)
+		e.uvarint(uint64(len(item.Metadata)))
+		// sorted keys keep files of equal indexes identical
+		keys := make([]string, 0, len(item.Metadata))
+		for key := range item.Metadata {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			e.string(key)
+			e.string(item.Metadata[key])
+		}
+		for _, v := range item.Vector {
+			e.uint32(math.Float32bits(v))
+		}
+	}
+
+	if e.err != nil {
+		return fmt.Errorf("failed to write vector index: %w", e.err)
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write vector index: %w", err)
+	}
+	return nil
+}
+
+// SaveFile writes the index to path. The file is replaced only after it was fully written.
+func (i *Index) SaveFile(path string) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create vector index file: %w", err)
+	}
+
+	err = i.Save(file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to close vector index file: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace vector index file: %w", err)
+	}
+	return nil
+}
+
+// Load reads an index written by Save.
+func Load(r io.Reader) (*Index, error) {
+	d := decoder{r: bufio.NewReader(r)}
+
+	magic := d.bytes(len(fileMagic))
+	version := d.bytes(1)
+	if d.err != nil {
+		return nil, fmt.Errorf("failed to read vector index: %w", d.err)
+	}
+	if string(magic) != fileMagic {
+		return nil, fmt.Errorf("not a vector index file")
+	}
+	if version[0] != fileVersion {
+		return nil, fmt.Errorf("unsupported vector index version %d", version[0])
+	}
+
+	index, err := New(Metric(d.string()))
+	if d.err != nil {
+		return nil, fmt.Errorf("failed to read vector index: %w", d.err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	dims := int(d.uint32())
+	count := int(d.uint32())
+	if dims > maxDimensions {
+		return nil, fmt.Errorf("vector index has %d dimensions, limit is %d", dims, maxDimensions)
+	}
+
+	for n := 0; n < count && d.err == nil; n++ {
+		id := d.string()
+		metadataCount := d.uvarint()
+		var metadata map[string]string
+		if metadataCount > 0 && d.err == nil {
+			metadata = make(map[string]string, min(metadataCount, 64))
+			for m := uint64(0); m < metadataCount && d.err == nil; m++ {
+				key := d.string()
+				metadata[key] = d.string()
+			}
+		}
+		vector := make([]float32, dims)
+		for j := range vector {
+			vector[j] = math.Float32frombits(d.uint32())
+		}
+		if d.err != nil {
+			break
+		}
+		if err := index.AddVector(id, vector, metadata); err != nil {
+			return nil, fmt.Errorf("invalid vector index item %d: %w", n, err)
+		}
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("failed to read vector index: %w", d.err)
+	}
+	index.dims = dims
+	return index, nil
+}
+
+// LoadFile reads an index written by SaveFile.
+func LoadFile(path string) (*Index, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vector index file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Warning: failed to close vector index file: %v", err)
+		}
+	}()
+	return Load(file)
+}
+
+// encoder keeps the first write error so Save can check once.
+type encoder struct {
+	w   io.Writer
+	err error
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (e *encoder) bytes(b []byte) {
+	if e.err == nil {
+		_, e.err = e.w.Write(b)
+	}
+}
+
+func (e *encoder) uvarint(v uint64) {
+	n := binary.PutUvarint(e.buf[:], v)
+	e.bytes(e.buf[:n])
+}
+
+func (e *encoder) uint32(v uint32) {
+	binary.LittleEndian.PutUint32(e.buf[:4], v)
+	e.bytes(e.buf[:4])
+}
+
+func (e *encoder) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.bytes([]byte(s))
+}
+
+// decoder keeps the first read error, later reads return zero values.
+type decoder struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (d *decoder) bytes(n int) []byte {
+	if d.err != nil {
+		return make([]byte, n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		d.err = err
+	}
+	return b
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		d.err = err
+	}
+	return v
+}
+
+func (d *decoder) uint32() uint32 {
+	return binary.LittleEndian.Uint32(d.bytes(4))
+}
+
+func (d *decoder) string() string {
+	size := d.uvarint()
+	if size > maxStringSize {
+		d.err = fmt.Errorf("string of %d bytes exceeds limit", size)
+		return ""
+	}
+	return string(d.bytes(int(size)))
+}
@@ -0,0 +1,84 @@
+package vectorindex_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/vectorindex"
+)
+
+func TestIndex_SaveLoad(t *testing.T) {
+	index := testIndex(t, vectorindex.METRIC_L2)
+
+	var buf bytes.Buffer
+	require.NoError(t, index.Save(&buf))
+	// header 19 bytes, items: 3 ids, 6 metadata pairs, 6 floats
+	assert.Less(t, buf.Len(), 150)
+
+	loaded, err := vectorindex.Load(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, vectorindex.METRIC_L2, loaded.Metric())
+	assert.Equal(t, 2, loaded.Dimensions())
+	assert.Equal(t, 3, loaded.Len())
+
+	item, ok := loaded.Get("xy")
+	require.True(t, ok)
+	assert.Equal(t, vectorindex.Item{ID: "xy", Vector: []float32{3, 3}, Metadata: map[string]string{"lang": "en", "kind": "faq"}}, item)
+
+	results, err := loaded.SearchVector([]float32{1, 0.1}, 3, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x", "y", "xy"}, resultIDs(results))
+
+	// equal indexes give equal files
+	var again bytes.Buffer
+	require.NoError(t, loaded.Save(&again))
+	assert.Equal(t, buf.Bytes(), again.Bytes())
+}
+
+func TestIndex_SaveLoadFile(t *testing.T) {
+	index := testIndex(t, vectorindex.METRIC_COSINE)
+	path := filepath.Join(t.TempDir(), "docs.idx")
+
+	require.NoError(t, index.SaveFile(path))
+	_, err := os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	loaded, err := vectorindex.LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loaded.Len())
+
+	empty, err := vectorindex.New(vectorindex.METRIC_DOT)
+	require.NoError(t, err)
+	require.NoError(t, empty.SaveFile(path))
+	loaded, err = vectorindex.LoadFile(path)
+	require.NoError(t, err)
+	assert.Zero(t, loaded.Len())
+
+	_, err = vectorindex.LoadFile(filepath.Join(t.TempDir(), "missing.idx"))
+	assert.ErrorContains(t, err, "failed to open vector index file")
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testIndex(t, vectorindex.METRIC_COSINE).Save(&buf))
+	data := buf.Bytes()
+
+	_, err := vectorindex.Load(bytes.NewReader([]byte("JUNKDATA")))
+	assert.ErrorContains(t, err, "not a vector index file")
+
+	_, err = vectorindex.Load(bytes.NewReader(data[:len(data)-3]))
+	assert.ErrorContains(t, err, "failed to read vector index")
+
+	version := bytes.Clone(data)
+	version[4] = 9
+	_, err = vectorindex.Load(bytes.NewReader(version))
+	assert.ErrorContains(t, err, "unsupported vector index version 9")
+
+	_, err = vectorindex.Load(bytes.NewReader(nil))
+	assert.ErrorContains(t, err, "failed to read vector index")
+}
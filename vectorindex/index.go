@@ -0,0 +1,246 @@
+// Package vectorindex is a small in-memory vector index for embeddings.
+// It is meant for retrieval over thousands of documents without a vector database.
+package vectorindex
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+type Metric string
+
+const (
+	// METRIC_COSINE scores by cosine similarity, from -1 to 1.
+	METRIC_COSINE Metric = "cosine"
+	// METRIC_DOT scores by dot product, useful for normalized embeddings.
+	METRIC_DOT Metric = "dot"
+	// METRIC_L2 scores by negative euclidean distance, so higher is closer like the other metrics.
+	METRIC_L2 Metric = "l2"
+)
+
+func (m Metric) validate() error {
+	switch m {
+	case METRIC_COSINE, METRIC_DOT, METRIC_L2:
+		return nil
+	}
+	return fmt.Errorf("unknown metric %q", m)
+}
+
+// Item is one stored vector.
+type Item struct {
+	ID       string
+	Vector   []float32
+	Metadata map[string]string
+}
+
+// Result is a search hit. Higher scores are more similar.
+type Result struct {
+	Item
+	Score float64
+}
+
+// Filter selects items by metadata. A nil Filter matches everything.
+type Filter func(metadata map[string]string) bool
+
+// Where matches items whose metadata contains all given key/value pairs.
+func Where(match map[string]string) Filter {
+	return func(metadata map[string]string) bool {
+		for key, value := range match {
+			if v, ok := metadata[key]; !ok || v != value {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Index stores vectors of one dimension. It is safe for concurrent use,
+// searches run in parallel and block only while items are added or deleted.
+type Index struct {
+	mu     sync.RWMutex
+	metric Metric
+	dims   int
+	items  []Item
+	norms  []float64
+	ids    map[string]int
+}
+
+// New creates an empty index. The dimension is taken from the first added vector.
+func New(metric Metric) (*Index, error) {
+	if err := metric.validate(); err != nil {
+		return nil, err
+	}
+	return &Index{metric: metric, ids: make(map[string]int)}, nil
+}
+
+func (i *Index) Metric() Metric {
+	return i.metric
+}
+
+// Dimensions returns the vector size, 0 while the index is empty and was never filled.
+func (i *Index) Dimensions() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.dims
+}
+
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.items)
+}
+
+// Add stores an embedding under id, replacing an existing item with the same id.
+func (i *Index) Add(id string, embedding response.Embedding, metadata map[string]string) error {
+	return i.AddVector(id, embedding.Float32(), metadata)
+}
+
+// AddVector is Add for float32 vectors. The vector and metadata are not copied.
+func (i *Index) AddVector(id string, vector []float32, metadata map[string]string) error {
+	if id == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if len(vector) == 0 {
+		return fmt.Errorf("vector %q is empty", id)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.dims == 0 {
+		i.dims = len(vector)
+	}
+	if len(vector) != i.dims {
+		return fmt.Errorf("vector %q has %d dimensions, index has %d", id, len(vector), i.dims)
+	}
+
+	item := Item{ID: id, Vector: vector, Metadata: metadata}
+	if pos, ok := i.ids[id]; ok {
+		i.items[pos] = item
+		i.norms[pos] = norm(vector)
+		return nil
+	}
+	i.ids[id] = len(i.items)
+	i.items = append(i.items, item)
+	i.norms = append(i.norms, norm(vector))
+	return nil
+}
+
+// Delete removes id and reports whether it was stored.
+func (i *Index) Delete(id string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	pos, ok := i.ids[id]
+	if !ok {
+		return false
+	}
+	last := len(i.items) - 1
+	if pos != last {
+		i.items[pos] = i.items[last]
+		i.norms[pos] = i.norms[last]
+		i.ids[i.items[pos].ID] = pos
+	}
+	i.items[last] = Item{}
+	i.items = i.items[:last]
+	i.norms = i.norms[:last]
+	delete(i.ids, id)
+	return true
+}
+
+func (i *Index) Get(id string) (Item, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	pos, ok := i.ids[id]
+	if !ok {
+		return Item{}, false
+	}
+	return i.items[pos], true
+}
+
+// Search returns the k items most similar to query that match filter, best first.
+func (i *Index) Search(query response.Embedding, k int, filter Filter) ([]Result, error) {
+	return i.SearchVector(query.Float32(), k, filter)
+}
+
+// SearchVector is Search for float32 vectors.
+func (i *Index) SearchVector(query []float32, k int, filter Filter) ([]Result, error) {
+	if k <= 0 {
+		return []Result{}, nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if len(i.items) == 0 {
+		return []Result{}, nil
+	}
+	if len(query) != i.dims {
+		return nil, fmt.Errorf("query has %d dimensions, index has %d", len(query), i.dims)
+	}
+
+	queryNorm := norm(query)
+	results := make([]Result, 0, min(k, len(i.items))+1)
+	for pos, item := range i.items {
+		if filter != nil && !filter(item.Metadata) {
+			continue
+		}
+		result := Result{Item: item, Score: i.score(query, queryNorm, pos)}
+		// keep the best k sorted, most indexes are small so insertion is cheap
+		at, _ := slices.BinarySearchFunc(results, result, compareResults)
+		if at >= k {
+			continue
+		}
+		results = slices.Insert(results, at, result)
+		if len(results) > k {
+			results = results[:k]
+		}
+	}
+	return results, nil
+}
+
+// compareResults orders by score descending, then by id for stable output.
+func compareResults(a, b Result) int {
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func (i *Index) score(query []float32, queryNorm float64, pos int) float64 {
+	vector := i.items[pos].Vector
+	switch i.metric {
+	case METRIC_DOT:
+		return dot(query, vector)
+	case METRIC_L2:
+		sum := 0.0
+		for j, v := range vector {
+			d := float64(query[j]) - float64(v)
+			sum += d * d
+		}
+		return -math.Sqrt(sum)
+	}
+
+	if queryNorm == 0 || i.norms[pos] == 0 {
+		return 0
+	}
+	return dot(query, vector) / (queryNorm * i.norms[pos])
+}
+
+func dot(a, b []float32) float64 {
+	sum := 0.0
+	for j, v := range a {
+		sum += float64(v) * float64(b[j])
+	}
+	return sum
+}
+
+func norm(v []float32) float64 {
+	return math.Sqrt(dot(v, v))
+}
@@ -0,0 +1,145 @@
+package vectorindex_test
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/response"
+	"github.com/andrejsstepanovs/go-litellm/vectorindex"
+)
+
+func testIndex(t *testing.T, metric vectorindex.Metric) *vectorindex.Index {
+	t.Helper()
+	index, err := vectorindex.New(metric)
+	require.NoError(t, err)
+
+	require.NoError(t, index.Add("x", response.Embedding{1, 0}, map[string]string{"lang": "en", "kind": "doc"}))
+	require.NoError(t, index.Add("y", response.Embedding{0, 2}, map[string]string{"lang": "de", "kind": "doc"}))
+	require.NoError(t, index.Add("xy", response.Embedding{3, 3}, map[string]string{"lang": "en", "kind": "faq"}))
+	return index
+}
+
+func resultIDs(results []vectorindex.Result) []string {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	return ids
+}
+
+func TestIndex_Search_Metrics(t *testing.T) {
+	query := response.Embedding{1, 0.1}
+
+	tests := []struct {
+		metric vectorindex.Metric
+		ids    []string
+		best   float64
+	}{
+		// cosine ignores length, dot favours long vectors, l2 favours close points
+		{metric: vectorindex.METRIC_COSINE, ids: []string{"x", "xy", "y"}, best: 1 / math.Sqrt(1.01)},
+		{metric: vectorindex.METRIC_DOT, ids: []string{"xy", "x", "y"}, best: 3.3},
+		{metric: vectorindex.METRIC_L2, ids: []string{"x", "y", "xy"}, best: -0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.metric), func(t *testing.T) {
+			index := testIndex(t, tt.metric)
+			results, err := index.Search(query, 10, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ids, resultIDs(results))
+			assert.InDelta(t, tt.best, results[0].Score, 1e-6)
+		})
+	}
+}
+
+func TestIndex_Search_TopKAndFilter(t *testing.T) {
+	index := testIndex(t, vectorindex.METRIC_COSINE)
+
+	results, err := index.Search(response.Embedding{1, 0.1}, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"x"}, resultIDs(results))
+	assert.Equal(t, "en", results[0].Metadata["lang"])
+
+	results, err = index.Search(response.Embedding{1, 0.1}, 5, vectorindex.Where(map[string]string{"lang": "en", "kind": "faq"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"xy"}, resultIDs(results))
+
+	results, err = index.Search(response.Embedding{1, 0.1}, 5, func(metadata map[string]string) bool { return metadata["lang"] == "fr" })
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	results, err = index.Search(response.Embedding{1, 0.1}, 0, nil)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = index.Search(response.Embedding{1, 0, 0}, 1, nil)
+	assert.ErrorContains(t, err, "query has 3 dimensions, index has 2")
+}
+
+func TestIndex_AddDelete(t *testing.T) {
+	index := testIndex(t, vectorindex.METRIC_COSINE)
+	assert.Equal(t, 3, index.Len())
+	assert.Equal(t, 2, index.Dimensions())
+
+	// replace keeps one item per id
+	require.NoError(t, index.Add("x", response.Embedding{-1, 0}, map[string]string{"lang": "fr"}))
+	assert.Equal(t, 3, index.Len())
+	item, ok := index.Get("x")
+	require.True(t, ok)
+	assert.Equal(t, []float32{-1, 0}, item.Vector)
+	assert.Equal(t, "fr", item.Metadata["lang"])
+
+	assert.True(t, index.Delete("x"))
+	assert.False(t, index.Delete("x"))
+	assert.Equal(t, 2, index.Len())
+	_, ok = index.Get("x")
+	assert.False(t, ok)
+
+	// moved items stay reachable after a delete
+	item, ok = index.Get("xy")
+	require.True(t, ok)
+	assert.Equal(t, []float32{3, 3}, item.Vector)
+	results, err := index.Search(response.Embedding{1, 1}, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"xy"}, resultIDs(results))
+
+	assert.ErrorContains(t, index.Add("z", response.Embedding{1, 2, 3}, nil), "has 3 dimensions, index has 2")
+	assert.ErrorContains(t, index.Add("", response.Embedding{1, 2}, nil), "id cannot be empty")
+	assert.ErrorContains(t, index.Add("z", response.Embedding{}, nil), "is empty")
+
+	_, err = vectorindex.New("manhattan")
+	assert.ErrorContains(t, err, `unknown metric "manhattan"`)
+}
+
+func TestIndex_Concurrent(t *testing.T) {
+	index, err := vectorindex.New(vectorindex.METRIC_DOT)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.NoError(t, index.AddVector(fmt.Sprintf("%d-%d", w, i), []float32{float32(i), 1}, nil))
+				if i%3 == 0 {
+					index.Delete(fmt.Sprintf("%d-%d", w, i/2))
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, err := index.SearchVector([]float32{1, 1}, 5, nil)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Positive(t, index.Len())
+}
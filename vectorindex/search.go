@@ -0,0 +1,45 @@
+package vectorindex
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+)
+
+// Embedder creates embeddings, *client.Litellm implements it.
+type Embedder interface {
+	EmbeddingsRequest(ctx context.Context, req request.EmbeddingRequest) (response.EmbeddingResponse, error)
+}
+
+// Searcher embeds text queries and searches an index in one call.
+// Model and Params must match the ones used for the indexed documents.
+type Searcher struct {
+	Index    *Index
+	Embedder Embedder
+	Model    models.ModelMeta
+	Params   request.EmbeddingParams
+}
+
+// Search embeds query and returns the k most similar items that match filter.
+func (s Searcher) Search(ctx context.Context, query string, k int, filter Filter) ([]Result, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query cannot be empty")
+	}
+
+	res, err := s.Embedder.EmbeddingsRequest(ctx, request.EmbeddingRequest{
+		Model:           string(s.Model.ModelId),
+		Input:           query,
+		EmbeddingParams: s.Params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if len(res.Data) == 0 {
+		return nil, fmt.Errorf("no embedding returned for query")
+	}
+
+	return s.Index.Search(res.Data[0].Embedding, k, filter)
+}
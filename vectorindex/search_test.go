@@ -0,0 +1,59 @@
+package vectorindex_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/andrejsstepanovs/go-litellm/client"
+	"github.com/andrejsstepanovs/go-litellm/models"
+	"github.com/andrejsstepanovs/go-litellm/request"
+	"github.com/andrejsstepanovs/go-litellm/response"
+	"github.com/andrejsstepanovs/go-litellm/vectorindex"
+)
+
+var _ vectorindex.Embedder = (*client.Litellm)(nil)
+
+type fakeEmbedder struct {
+	requests []request.EmbeddingRequest
+	vector   response.Embedding
+	err      error
+}
+
+func (f *fakeEmbedder) EmbeddingsRequest(_ context.Context, req request.EmbeddingRequest) (response.EmbeddingResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return response.EmbeddingResponse{}, f.err
+	}
+	return response.EmbeddingResponse{Data: []response.EmbeddingData{{Embedding: f.vector}}}, nil
+}
+
+func TestSearcher_Search(t *testing.T) {
+	embedder := &fakeEmbedder{vector: response.Embedding{0, 1}}
+	searcher := vectorindex.Searcher{
+		Index:    testIndex(t, vectorindex.METRIC_COSINE),
+		Embedder: embedder,
+		Model:    models.ModelMeta{ModelId: "text-embedding-3-small"},
+		Params:   request.EmbeddingParams{Dimensions: 2},
+	}
+
+	results, err := searcher.Search(context.Background(), "german docs", 2, vectorindex.Where(map[string]string{"kind": "doc"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"y", "x"}, resultIDs(results))
+	require.Len(t, embedder.requests, 1)
+	assert.Equal(t, request.EmbeddingRequest{
+		Model:           "text-embedding-3-small",
+		Input:           "german docs",
+		EmbeddingParams: request.EmbeddingParams{Dimensions: 2},
+	}, embedder.requests[0])
+
+	_, err = searcher.Search(context.Background(), "", 2, nil)
+	assert.ErrorContains(t, err, "query cannot be empty")
+
+	embedder.err = errors.New("rate limited")
+	_, err = searcher.Search(context.Background(), "query", 2, nil)
+	assert.ErrorContains(t, err, "failed to embed query: rate limited")
+}